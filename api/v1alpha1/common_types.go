@@ -0,0 +1,30 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// NonAdminCondition is the type of condition shared across NonAdmin* object Status
+type NonAdminCondition string
+
+const (
+	// NonAdminConditionAccepted means the Spec of the NonAdmin object passed validation
+	NonAdminConditionAccepted NonAdminCondition = "Accepted"
+	// NonAdminConditionQueued means the corresponding Velero object was created in the OADP namespace
+	NonAdminConditionQueued NonAdminCondition = "Queued"
+	// NonAdminConditionDeleting means the object is being deleted and is waiting on the removal of
+	// the data it created in the OADP namespace and/or object storage
+	NonAdminConditionDeleting NonAdminCondition = "Deleting"
+)
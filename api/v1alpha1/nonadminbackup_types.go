@@ -0,0 +1,123 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	velerov1 "github.com/vmware-tanzu/velero/pkg/apis/velero/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NonAdminBackupPhase represents the lifecycle phase of a NonAdminBackup object
+type NonAdminBackupPhase string
+
+const (
+	// NonAdminBackupPhaseNew means the object has been created but not yet reconciled
+	NonAdminBackupPhaseNew NonAdminBackupPhase = "New"
+	// NonAdminBackupPhaseBackingOff means the BackupSpec failed validation
+	NonAdminBackupPhaseBackingOff NonAdminBackupPhase = "BackingOff"
+	// NonAdminBackupPhaseCreated means the VeleroBackup was created from this object
+	NonAdminBackupPhaseCreated NonAdminBackupPhase = "Created"
+	// NonAdminBackupPhaseDeleting means the object is being deleted and the associated VeleroBackup
+	// is being removed from object storage through a VeleroBackup DeleteBackupRequest
+	NonAdminBackupPhaseDeleting NonAdminBackupPhase = "Deleting"
+)
+
+// NonAdminBackupSpec defines the desired state of NonAdminBackup
+type NonAdminBackupSpec struct {
+	// BackupSpec defines the configuration for the Velero backup
+	// +optional
+	BackupSpec *velerov1.BackupSpec `json:"backupSpec,omitempty"`
+}
+
+// VeleroBackup contains information about the Velero Backup generated from the NonAdminBackup
+type VeleroBackup struct {
+	// Name of the Velero Backup
+	Name string `json:"name,omitempty"`
+	// Namespace of the Velero Backup, this is the OADP namespace
+	Namespace string `json:"namespace,omitempty"`
+	// Status mirrors the Status of the Velero Backup
+	// +optional
+	Status *velerov1.BackupStatus `json:"status,omitempty"`
+}
+
+// VolumeSnapshotSummary is a redacted summary of a VolumeSnapshot created by the VeleroBackup CSI plugin,
+// exposed so the NonAdminBackup owner can see what was snapshotted without requiring RBAC to read
+// VolumeSnapshotContent objects
+type VolumeSnapshotSummary struct {
+	// Name of the VolumeSnapshot
+	Name string `json:"name"`
+	// SourcePVC is the name of the PersistentVolumeClaim the VolumeSnapshot was taken from
+	// +optional
+	SourcePVC string `json:"sourcePVC,omitempty"`
+	// ReadyToUse reports whether the VolumeSnapshot is ready to be used to restore a volume
+	// +optional
+	ReadyToUse *bool `json:"readyToUse,omitempty"`
+	// RestoreSize is the minimum size of volume required to rehydrate from the snapshot
+	// +optional
+	RestoreSize *resource.Quantity `json:"restoreSize,omitempty"`
+	// SnapshotHandle is the unique identifier of the snapshot taken by the storage backend
+	// +optional
+	SnapshotHandle string `json:"snapshotHandle,omitempty"`
+}
+
+// NonAdminBackupStatus defines the observed state of NonAdminBackup
+type NonAdminBackupStatus struct {
+	// Phase of the NonAdminBackup
+	// +optional
+	Phase NonAdminBackupPhase `json:"phase,omitempty"`
+
+	// Conditions represent the latest available observations of the NonAdminBackup state
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// VeleroBackup references the Velero Backup generated from this object
+	// +optional
+	VeleroBackup *VeleroBackup `json:"veleroBackup,omitempty"`
+
+	// VolumeSnapshots lists a redacted summary of the VolumeSnapshots created by the VeleroBackup, once it
+	// reaches the Completed phase
+	// +optional
+	VolumeSnapshots []VolumeSnapshotSummary `json:"volumeSnapshots,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// NonAdminBackup is the Schema for the nonadminbackups API
+type NonAdminBackup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   NonAdminBackupSpec   `json:"spec,omitempty"`
+	Status NonAdminBackupStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// NonAdminBackupList contains a list of NonAdminBackup
+type NonAdminBackupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NonAdminBackup `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&NonAdminBackup{}, &NonAdminBackupList{})
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	velerov1 "github.com/vmware-tanzu/velero/pkg/apis/velero/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NonAdminRestorePhase represents the lifecycle phase of a NonAdminRestore object
+type NonAdminRestorePhase string
+
+const (
+	// NonAdminRestorePhaseNew means the object has been created but not yet reconciled
+	NonAdminRestorePhaseNew NonAdminRestorePhase = "New"
+	// NonAdminRestorePhaseBackingOff means the RestoreSpec failed validation
+	NonAdminRestorePhaseBackingOff NonAdminRestorePhase = "BackingOff"
+	// NonAdminRestorePhaseCreated means the VeleroRestore was created from this object
+	NonAdminRestorePhaseCreated NonAdminRestorePhase = "Created"
+)
+
+// NonAdminRestoreSpec defines the desired state of NonAdminRestore
+type NonAdminRestoreSpec struct {
+	// NonAdminBackupName is the name of the NonAdminBackup, in the same namespace, to restore from
+	// +kubebuilder:validation:Required
+	NonAdminBackupName string `json:"nonAdminBackupName"`
+
+	// RestoreSpec defines the configuration for the Velero restore
+	// +optional
+	RestoreSpec *velerov1.RestoreSpec `json:"restoreSpec,omitempty"`
+}
+
+// VeleroRestore contains information about the Velero Restore generated from the NonAdminRestore
+type VeleroRestore struct {
+	// Name of the Velero Restore
+	Name string `json:"name,omitempty"`
+	// Namespace of the Velero Restore, this is the OADP namespace
+	Namespace string `json:"namespace,omitempty"`
+	// Status mirrors the Status of the Velero Restore
+	// +optional
+	Status *velerov1.RestoreStatus `json:"status,omitempty"`
+}
+
+// NonAdminRestoreStatus defines the observed state of NonAdminRestore
+type NonAdminRestoreStatus struct {
+	// Phase of the NonAdminRestore
+	// +optional
+	Phase NonAdminRestorePhase `json:"phase,omitempty"`
+
+	// Conditions represent the latest available observations of the NonAdminRestore state
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// VeleroRestore references the Velero Restore generated from this object
+	// +optional
+	VeleroRestore *VeleroRestore `json:"veleroRestore,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// NonAdminRestore is the Schema for the nonadminrestores API
+type NonAdminRestore struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   NonAdminRestoreSpec   `json:"spec,omitempty"`
+	Status NonAdminRestoreStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// NonAdminRestoreList contains a list of NonAdminRestore
+type NonAdminRestoreList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NonAdminRestore `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&NonAdminRestore{}, &NonAdminRestoreList{})
+}
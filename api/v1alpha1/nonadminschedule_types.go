@@ -0,0 +1,94 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	velerov1 "github.com/vmware-tanzu/velero/pkg/apis/velero/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NonAdminSchedulePhase represents the lifecycle phase of a NonAdminSchedule object
+type NonAdminSchedulePhase string
+
+const (
+	// NonAdminSchedulePhaseNew means the object has been created but not yet reconciled
+	NonAdminSchedulePhaseNew NonAdminSchedulePhase = "New"
+	// NonAdminSchedulePhaseBackingOff means the ScheduleSpec failed validation
+	NonAdminSchedulePhaseBackingOff NonAdminSchedulePhase = "BackingOff"
+	// NonAdminSchedulePhaseEnabled means the VeleroSchedule was created from this object
+	NonAdminSchedulePhaseEnabled NonAdminSchedulePhase = "Enabled"
+)
+
+// NonAdminScheduleSpec defines the desired state of NonAdminSchedule
+type NonAdminScheduleSpec struct {
+	// ScheduleSpec defines the configuration for the Velero schedule
+	// +optional
+	ScheduleSpec *velerov1.ScheduleSpec `json:"scheduleSpec,omitempty"`
+}
+
+// VeleroSchedule contains information about the Velero Schedule generated from the NonAdminSchedule
+type VeleroSchedule struct {
+	// Name of the Velero Schedule
+	Name string `json:"name,omitempty"`
+	// Namespace of the Velero Schedule, this is the OADP namespace
+	Namespace string `json:"namespace,omitempty"`
+	// Status mirrors the Status of the Velero Schedule
+	// +optional
+	Status *velerov1.ScheduleStatus `json:"status,omitempty"`
+}
+
+// NonAdminScheduleStatus defines the observed state of NonAdminSchedule
+type NonAdminScheduleStatus struct {
+	// Phase of the NonAdminSchedule
+	// +optional
+	Phase NonAdminSchedulePhase `json:"phase,omitempty"`
+
+	// Conditions represent the latest available observations of the NonAdminSchedule state
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// VeleroSchedule references the Velero Schedule generated from this object
+	// +optional
+	VeleroSchedule *VeleroSchedule `json:"veleroSchedule,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// NonAdminSchedule is the Schema for the nonadminschedules API
+type NonAdminSchedule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   NonAdminScheduleSpec   `json:"spec,omitempty"`
+	Status NonAdminScheduleStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// NonAdminScheduleList contains a list of NonAdminSchedule
+type NonAdminScheduleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NonAdminSchedule `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&NonAdminSchedule{}, &NonAdminScheduleList{})
+}
@@ -0,0 +1,30 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package constant contains all constants shared by the project
+package constant
+
+const (
+	// EmptyString represents an empty string value, used for comparisons and zero-value checks
+	EmptyString = ""
+
+	// OadpNamespaceEnvVar is the name of the environment variable holding the OADP namespace
+	OadpNamespaceEnvVar = "WATCH_NAMESPACE"
+
+	// NonAdminBackupFinalizerName is the finalizer added to NonAdminBackup objects so the controller can
+	// cascade delete the associated VeleroBackup, and its object storage data, before the object is removed
+	NonAdminBackupFinalizerName = "nac.oadp.openshift.io/finalizer"
+)
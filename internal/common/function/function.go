@@ -0,0 +1,215 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package function contains all common functions used by the project
+package function
+
+import (
+	"context"
+	"crypto/sha1" //nolint:gosec // not used for cryptographic purposes
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/robfig/cron/v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	nacv1alpha1 "github.com/migtools/oadp-non-admin/api/v1alpha1"
+	"github.com/migtools/oadp-non-admin/internal/common/constant"
+)
+
+const (
+	nonAdminLabelKey   = "openshift.io/oadp-non-admin"
+	nonAdminLabelValue = "True"
+
+	// NabOriginNameAnnotation is the annotation key holding the name of the NonAdminBackup that originated
+	// a generated VeleroBackup
+	NabOriginNameAnnotation = "openshift.io/oadp-non-admin-backup-name"
+	// NabOriginNamespaceAnnotation is the annotation key holding the namespace of the NonAdminBackup that
+	// originated a generated VeleroBackup
+	NabOriginNamespaceAnnotation = "openshift.io/oadp-non-admin-backup-namespace"
+	// NarOriginNameAnnotation is the annotation key holding the name of the NonAdminRestore that originated
+	// a generated VeleroRestore
+	NarOriginNameAnnotation = "openshift.io/oadp-non-admin-restore-name"
+	// NarOriginNamespaceAnnotation is the annotation key holding the namespace of the NonAdminRestore that
+	// originated a generated VeleroRestore
+	NarOriginNamespaceAnnotation = "openshift.io/oadp-non-admin-restore-namespace"
+	// NasOriginNameAnnotation is the annotation key holding the name of the NonAdminSchedule that originated
+	// a generated VeleroSchedule
+	NasOriginNameAnnotation = "openshift.io/oadp-non-admin-schedule-name"
+	// NasOriginNamespaceAnnotation is the annotation key holding the namespace of the NonAdminSchedule that
+	// originated a generated VeleroSchedule
+	NasOriginNamespaceAnnotation = "openshift.io/oadp-non-admin-schedule-namespace"
+	// AdoptedVeleroBackupNameAnnotation is the annotation key holding the name of the VeleroBackup a
+	// NonAdminBackup was adopted from, set at creation time so it is visible before the Status subresource
+	// is populated
+	AdoptedVeleroBackupNameAnnotation = "openshift.io/oadp-non-admin-adopted-backup-name"
+
+	// cronValidationSampleSize is the number of consecutive intervals between firings sampled by
+	// ValidateScheduleSpec when looking for the narrowest one
+	cronValidationSampleSize = 5
+)
+
+// GetNonAdminLabels return the required Non Admin labels, to be added to generated Velero objects
+func GetNonAdminLabels() map[string]string {
+	return map[string]string{
+		nonAdminLabelKey: nonAdminLabelValue,
+	}
+}
+
+// GetNonAdminBackupAnnotations return the required Non Admin annotations, for the generated VeleroBackup, that
+// identify which NonAdminBackup originated it
+func GetNonAdminBackupAnnotations(objectMeta metav1.ObjectMeta) map[string]string {
+	return map[string]string{
+		NabOriginNameAnnotation:      objectMeta.Name,
+		NabOriginNamespaceAnnotation: objectMeta.Namespace,
+	}
+}
+
+// GetNonAdminRestoreAnnotations return the required Non Admin annotations, for the generated VeleroRestore, that
+// identify which NonAdminRestore originated it
+func GetNonAdminRestoreAnnotations(objectMeta metav1.ObjectMeta) map[string]string {
+	return map[string]string{
+		NarOriginNameAnnotation:      objectMeta.Name,
+		NarOriginNamespaceAnnotation: objectMeta.Namespace,
+	}
+}
+
+// GetNonAdminScheduleAnnotations return the required Non Admin annotations, for the generated VeleroSchedule, that
+// identify which NonAdminSchedule originated it
+func GetNonAdminScheduleAnnotations(objectMeta metav1.ObjectMeta) map[string]string {
+	return map[string]string{
+		NasOriginNameAnnotation:      objectMeta.Name,
+		NasOriginNamespaceAnnotation: objectMeta.Namespace,
+	}
+}
+
+// GenerateVeleroBackupName generates the Velero Backup name, based on the NonAdminBackup's namespace and name, so
+// it does not clash with names already in use in the OADP namespace
+func GenerateVeleroBackupName(namespace, nabName string) string {
+	if namespace == constant.EmptyString || nabName == constant.EmptyString {
+		return constant.EmptyString
+	}
+	return fmt.Sprintf("nab-%s-%s", namespace, generateSuffix(namespace, nabName))
+}
+
+// GenerateVeleroScheduleName generates the Velero Schedule name, based on the NonAdminSchedule's namespace and
+// name, so it does not clash with names already in use in the OADP namespace
+func GenerateVeleroScheduleName(namespace, nasName string) string {
+	if namespace == constant.EmptyString || nasName == constant.EmptyString {
+		return constant.EmptyString
+	}
+	return fmt.Sprintf("nas-%s-%s", namespace, generateSuffix(namespace, nasName))
+}
+
+// GenerateVeleroRestoreName generates the Velero Restore name, based on the NonAdminRestore's namespace and name, so
+// it does not clash with names already in use in the OADP namespace
+func GenerateVeleroRestoreName(namespace, narName string) string {
+	if namespace == constant.EmptyString || narName == constant.EmptyString {
+		return constant.EmptyString
+	}
+	return fmt.Sprintf("nar-%s-%s", namespace, generateSuffix(namespace, narName))
+}
+
+// generateSuffix returns a deterministic, DNS-1123 safe suffix derived from namespace and name
+func generateSuffix(namespace, name string) string {
+	hash := sha1.Sum([]byte(fmt.Sprintf("%s/%s", namespace, name))) //nolint:gosec // not used for cryptographic purposes
+	return fmt.Sprintf("%x", hash)
+}
+
+// ErrCSISnapshotNotAllowed wraps a BackupSpec validation failure caused by the CSI snapshot policy allow
+// list, so callers can select a more specific status Reason than a generic invalid spec
+var ErrCSISnapshotNotAllowed = errors.New("CSI snapshot not allowed")
+
+// ValidateBackupSpec validates the BackupSpec from the NonAdminBackup object.
+// When csiSnapshotRequested is true, every StorageClass in usedStorageClasses must be present as a key in
+// allowedVolumeSnapshotClasses, the admin-provided allow list mapping a StorageClass to the
+// VolumeSnapshotClass permitted to snapshot it.
+func ValidateBackupSpec(nab *nacv1alpha1.NonAdminBackup, csiSnapshotRequested bool, usedStorageClasses []string, allowedVolumeSnapshotClasses map[string]string) error {
+	if nab.Spec.BackupSpec == nil {
+		return errors.New("BackupSpec is not defined")
+	}
+	if len(nab.Spec.BackupSpec.IncludedNamespaces) > 0 {
+		return errors.New("spec.backupSpec.IncludedNamespaces field value cannot be set, it's safe to leave this field empty")
+	}
+	if csiSnapshotRequested {
+		for _, storageClassName := range usedStorageClasses {
+			if _, allowed := allowedVolumeSnapshotClasses[storageClassName]; !allowed {
+				return fmt.Errorf("%w: StorageClass %q is not allow-listed for CSI snapshots", ErrCSISnapshotNotAllowed, storageClassName)
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateRestoreSpec validates the RestoreSpec from the NonAdminRestore object
+func ValidateRestoreSpec(nar *nacv1alpha1.NonAdminRestore) error {
+	if nar.Spec.NonAdminBackupName == constant.EmptyString {
+		return errors.New("spec.nonAdminBackupName cannot be empty")
+	}
+	if nar.Spec.RestoreSpec == nil {
+		return errors.New("RestoreSpec is not defined")
+	}
+	if len(nar.Spec.RestoreSpec.IncludedNamespaces) > 0 {
+		return errors.New("spec.restoreSpec.IncludedNamespaces field value cannot be set, it's safe to leave this field empty")
+	}
+	if len(nar.Spec.RestoreSpec.NamespaceMapping) > 0 {
+		return errors.New("spec.restoreSpec.NamespaceMapping field value cannot be set, restores are always confined to the NonAdminRestore namespace")
+	}
+	return nil
+}
+
+// ValidateScheduleSpec validates the ScheduleSpec from the NonAdminSchedule object, rejecting cron
+// expressions that would run more frequently than minInterval
+func ValidateScheduleSpec(nas *nacv1alpha1.NonAdminSchedule, minInterval time.Duration) error {
+	if nas.Spec.ScheduleSpec == nil {
+		return errors.New("ScheduleSpec is not defined")
+	}
+	if len(nas.Spec.ScheduleSpec.Template.IncludedNamespaces) > 0 {
+		return errors.New("spec.scheduleSpec.template.IncludedNamespaces field value cannot be set, it's safe to leave this field empty")
+	}
+
+	schedule, err := cron.ParseStandard(nas.Spec.ScheduleSpec.Schedule)
+	if err != nil {
+		return fmt.Errorf("invalid spec.scheduleSpec.schedule cron expression: %w", err)
+	}
+
+	// Cron expressions with irregular spacing (e.g. multiple days-of-month) can have a shortest
+	// interval that only shows up several firings in, so a handful of consecutive firings are
+	// sampled and the narrowest gap among them is compared against minInterval.
+	run := schedule.Next(time.Unix(0, 0))
+	for i := 0; i < cronValidationSampleSize; i++ {
+		nextRun := schedule.Next(run)
+		if interval := nextRun.Sub(run); interval < minInterval {
+			return fmt.Errorf("spec.scheduleSpec.schedule cron expression runs every %s, which is below the minimum allowed interval of %s", interval, minInterval)
+		}
+		run = nextRun
+	}
+
+	return nil
+}
+
+// GetLogger returns a Logger with the object's name, namespace and predicate that emitted it
+func GetLogger(ctx context.Context, object client.Object, predicate string) logr.Logger {
+	return log.FromContext(ctx).WithValues(
+		"Predicate", predicate,
+		"Name", object.GetName(),
+		"Namespace", object.GetNamespace(),
+	)
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package function
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	velerov1 "github.com/vmware-tanzu/velero/pkg/apis/velero/v1"
+
+	nacv1alpha1 "github.com/migtools/oadp-non-admin/api/v1alpha1"
+	"github.com/migtools/oadp-non-admin/internal/common/constant"
+)
+
+// TestValidateBackupSpec_CSISnapshotAllowList covers the CSI snapshot VolumeSnapshotClass allow-list
+// policy: every StorageClass in usedStorageClasses must be present in allowedVolumeSnapshotClasses
+// whenever csiSnapshotRequested is true, simulating what a CSI driver would have snapshotted.
+func TestValidateBackupSpec_CSISnapshotAllowList(t *testing.T) {
+	nab := &nacv1alpha1.NonAdminBackup{
+		Spec: nacv1alpha1.NonAdminBackupSpec{
+			BackupSpec: &velerov1.BackupSpec{},
+		},
+	}
+
+	tests := []struct {
+		name                 string
+		csiSnapshotRequested bool
+		usedStorageClasses   []string
+		allowList            map[string]string
+		wantErr              bool
+		wantErrIs            error
+	}{
+		{
+			name:                 "CSI snapshot not requested, unlisted StorageClasses are ignored",
+			csiSnapshotRequested: false,
+			usedStorageClasses:   []string{"unlisted-sc"},
+			allowList:            map[string]string{},
+		},
+		{
+			name:                 "allow-listed StorageClass is accepted",
+			csiSnapshotRequested: true,
+			usedStorageClasses:   []string{"gp3-csi"},
+			allowList:            map[string]string{"gp3-csi": "gp3-vsc"},
+		},
+		{
+			name:                 "StorageClass missing from allow list is rejected",
+			csiSnapshotRequested: true,
+			usedStorageClasses:   []string{"gp3-csi", "unlisted-sc"},
+			allowList:            map[string]string{"gp3-csi": "gp3-vsc"},
+			wantErr:              true,
+			wantErrIs:            ErrCSISnapshotNotAllowed,
+		},
+		{
+			name:                 "default StorageClass (empty key) can be explicitly allow-listed",
+			csiSnapshotRequested: true,
+			usedStorageClasses:   []string{constant.EmptyString},
+			allowList:            map[string]string{constant.EmptyString: "default-vsc"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateBackupSpec(nab, tt.csiSnapshotRequested, tt.usedStorageClasses, tt.allowList)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("ValidateBackupSpec() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErrIs != nil && !errors.Is(err, tt.wantErrIs) {
+				t.Fatalf("ValidateBackupSpec() error = %v, want errors.Is match for %v", err, tt.wantErrIs)
+			}
+		})
+	}
+}
+
+// TestValidateScheduleSpec_CronInterval covers the minimum cron interval enforcement: a cron expression
+// that would fire more often than minInterval must be rejected, one that fires at or above it must be
+// accepted, and minInterval == 0 must disable the check entirely.
+func TestValidateScheduleSpec_CronInterval(t *testing.T) {
+	newNAS := func(schedule string) *nacv1alpha1.NonAdminSchedule {
+		return &nacv1alpha1.NonAdminSchedule{
+			Spec: nacv1alpha1.NonAdminScheduleSpec{
+				ScheduleSpec: &velerov1.ScheduleSpec{Schedule: schedule},
+			},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		schedule    string
+		minInterval time.Duration
+		wantErr     bool
+	}{
+		{
+			name:        "cron below the minimum interval is rejected",
+			schedule:    "*/5 * * * *",
+			minInterval: time.Hour,
+			wantErr:     true,
+		},
+		{
+			name:        "cron at the minimum interval is accepted",
+			schedule:    "0 * * * *",
+			minInterval: time.Hour,
+			wantErr:     false,
+		},
+		{
+			name:        "cron above the minimum interval is accepted",
+			schedule:    "0 0 * * *",
+			minInterval: time.Hour,
+			wantErr:     false,
+		},
+		{
+			name:        "minInterval 0 disables the check, even for a very frequent cron",
+			schedule:    "* * * * *",
+			minInterval: 0,
+			wantErr:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateScheduleSpec(newNAS(tt.schedule), tt.minInterval)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("ValidateScheduleSpec() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -20,10 +20,15 @@ package controller
 import (
 	"context"
 	"errors"
+	"fmt"
 	"reflect"
+	"sort"
 
 	"github.com/go-logr/logr"
+	snapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v7/apis/volumesnapshot/v1"
 	velerov1 "github.com/vmware-tanzu/velero/pkg/apis/velero/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -31,6 +36,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
@@ -46,6 +52,15 @@ type NonAdminBackupReconciler struct {
 	client.Client
 	Scheme        *runtime.Scheme
 	OADPNamespace string
+	// VolumeSnapshotClassAllowList maps a StorageClass name to the VolumeSnapshotClass name the admin
+	// permits non-admin users to use for CSI snapshots of volumes provisioned by that StorageClass.
+	// StorageClasses absent from this map cannot be used for CSI snapshotting or SnapshotMoveData backups.
+	// A nil map (the zero value, e.g. the flag was never set) disables CSI snapshot policy enforcement
+	// entirely. A non-nil, empty map is a deliberate "forbid all CSI snapshots" policy: every CSI
+	// snapshot request is rejected because no StorageClass can satisfy the allow list. This distinction
+	// only exists because Go lets nil and empty maps be told apart (`== nil`); len() alone cannot express
+	// it, since both report a length of zero.
+	VolumeSnapshotClassAllowList map[string]string
 }
 
 type reconcileStepFunction func(ctx context.Context, logger logr.Logger, nab *nacv1alpha1.NonAdminBackup) (bool, error)
@@ -61,6 +76,11 @@ const (
 // +kubebuilder:rbac:groups=nac.oadp.openshift.io,resources=nonadminbackups/finalizers,verbs=update
 
 // +kubebuilder:rbac:groups=velero.io,resources=backups,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups=velero.io,resources=deletebackuprequests,verbs=get;list;watch;create;delete
+
+// +kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=get;list;watch
+// +kubebuilder:rbac:groups=snapshot.storage.k8s.io,resources=volumesnapshots,verbs=get;list;watch
+// +kubebuilder:rbac:groups=snapshot.storage.k8s.io,resources=volumesnapshotcontents,verbs=get;list;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state,
@@ -81,6 +101,10 @@ func (r *NonAdminBackupReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		return ctrl.Result{}, err
 	}
 
+	if !nab.DeletionTimestamp.IsZero() {
+		return r.reconcileDelete(ctx, logger, nab)
+	}
+
 	reconcileSteps := []reconcileStepFunction{
 		r.init,
 		r.validateSpec,
@@ -111,6 +135,17 @@ func (r *NonAdminBackupReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 // It then returns boolean values indicating whether the reconciliation loop should requeue or exit
 // and error value whether the status was updated successfully.
 func (r *NonAdminBackupReconciler) init(ctx context.Context, logger logr.Logger, nab *nacv1alpha1.NonAdminBackup) (bool, error) {
+	if !controllerutil.ContainsFinalizer(nab, constant.NonAdminBackupFinalizerName) {
+		controllerutil.AddFinalizer(nab, constant.NonAdminBackupFinalizerName)
+		if err := r.Update(ctx, nab); err != nil {
+			logger.Error(err, "Failed to add finalizer to NonAdminBackup")
+			return false, err
+		}
+
+		logger.V(1).Info("NonAdminBackup - Requeue after Finalizer Update")
+		return true, nil
+	}
+
 	if nab.Status.Phase == constant.EmptyString {
 		updated := updateNonAdminPhase(&nab.Status.Phase, nacv1alpha1.NonAdminBackupPhaseNew)
 		if updated {
@@ -141,14 +176,45 @@ func (r *NonAdminBackupReconciler) init(ctx context.Context, logger logr.Logger,
 // If the BackupSpec is invalid, the function sets the NonAdminBackup condition Accepted to "False".
 // If the BackupSpec is valid, the function sets the NonAdminBackup condition Accepted to "True".
 func (r *NonAdminBackupReconciler) validateSpec(ctx context.Context, logger logr.Logger, nab *nacv1alpha1.NonAdminBackup) (bool, error) {
-	err := function.ValidateBackupSpec(nab)
+	// The CSI snapshot policy is opt-in: with VolumeSnapshotClassAllowList left nil (never configured),
+	// the admin has not asked for it to be enforced, so every StorageClass is implicitly permitted. A
+	// non-nil but empty map is a deliberate "forbid all CSI snapshots" policy, which ValidateBackupSpec
+	// then fails closed on, since no StorageClass can be present in an empty allow list.
+	// The policy is enforced at namespace granularity, not against the BackupSpec's resource/label
+	// selectors: every StorageClass used anywhere in the namespace must be allow-listed, even if a given
+	// backup's selectors would not touch every PVC. This fails closed rather than requiring the
+	// controller to resolve which PVCs a given selector actually matches.
+	//
+	// A NonAdminBackup adopted from a NonAdminSchedule represents a VeleroBackup whose CSI snapshots, if
+	// any, were already taken before this NonAdminBackup ever existed. The policy cannot be retroactively
+	// enforced against a snapshot that already happened, so adopted NonAdminBackups are exempt. Adoption
+	// is recognized by AdoptedVeleroBackupNameAnnotation together with the controller OwnerReference
+	// adoptVeleroBackup sets, rather than the annotation alone, since a non-admin user otherwise controls
+	// every field of their own NonAdminBackup and could set the annotation themselves.
+	csiSnapshotRequested := !isAdoptedNonAdminBackup(nab) && r.VolumeSnapshotClassAllowList != nil && requestsCSISnapshot(nab.Spec.BackupSpec)
+	var usedStorageClasses []string
+	if csiSnapshotRequested {
+		var err error
+		usedStorageClasses, err = r.listNamespaceStorageClasses(ctx, nab.Namespace)
+		if err != nil {
+			logger.Error(err, "Unable to list PersistentVolumeClaims to validate CSI snapshot policy")
+			return false, err
+		}
+	}
+
+	err := function.ValidateBackupSpec(nab, csiSnapshotRequested, usedStorageClasses, r.VolumeSnapshotClassAllowList)
 	if err != nil {
+		reason := "InvalidBackupSpec"
+		if errors.Is(err, function.ErrCSISnapshotNotAllowed) {
+			reason = "CSISnapshotNotAllowed"
+		}
+
 		updatedPhase := updateNonAdminPhase(&nab.Status.Phase, nacv1alpha1.NonAdminBackupPhaseBackingOff)
 		updatedCondition := meta.SetStatusCondition(&nab.Status.Conditions,
 			metav1.Condition{
 				Type:    string(nacv1alpha1.NonAdminConditionAccepted),
 				Status:  metav1.ConditionFalse,
-				Reason:  "InvalidBackupSpec",
+				Reason:  reason,
 				Message: err.Error(),
 			},
 		)
@@ -196,7 +262,15 @@ func (r *NonAdminBackupReconciler) validateSpec(ctx context.Context, logger logr
 //	logger: Logger instance for logging messages.
 //	nab: Pointer to the NonAdminBackup object.
 func (r *NonAdminBackupReconciler) syncVeleroBackupWithNonAdminBackup(ctx context.Context, logger logr.Logger, nab *nacv1alpha1.NonAdminBackup) (bool, error) {
+	// A NonAdminBackup adopted from a NonAdminSchedule already points at a VeleroBackup whose name was
+	// chosen by Velero, not generated from the NonAdminBackup identity. The adopted name is annotated on
+	// the NonAdminBackup at creation time, so it is available even before Status.VeleroBackup is populated.
 	veleroBackupName := function.GenerateVeleroBackupName(nab.Namespace, nab.Name)
+	if nab.Status.VeleroBackup != nil && nab.Status.VeleroBackup.Name != constant.EmptyString {
+		veleroBackupName = nab.Status.VeleroBackup.Name
+	} else if adoptedName := nab.Annotations[function.AdoptedVeleroBackupNameAnnotation]; adoptedName != constant.EmptyString {
+		veleroBackupName = adoptedName
+	}
 	if veleroBackupName == constant.EmptyString {
 		return false, errors.New("unable to generate Velero Backup name")
 	}
@@ -267,9 +341,247 @@ func (r *NonAdminBackupReconciler) syncVeleroBackupWithNonAdminBackup(ctx contex
 		logger.V(1).Info("NonAdminBackup Status updated successfully")
 	}
 
+	return r.syncVolumeSnapshotsStatus(ctx, veleroBackupLogger, nab, &veleroBackup)
+}
+
+// isAdoptedNonAdminBackup reports whether nab represents a VeleroBackup adopted from a NonAdminSchedule.
+// A non-admin user controls every field of their own NonAdminBackup, including annotations, so the
+// AdoptedVeleroBackupNameAnnotation alone is not sufficient proof of adoption; it is checked together with
+// the controller OwnerReference to a NonAdminSchedule that adoptVeleroBackup sets, which a non-admin cannot
+// as easily forge.
+func isAdoptedNonAdminBackup(nab *nacv1alpha1.NonAdminBackup) bool {
+	if nab.Annotations[function.AdoptedVeleroBackupNameAnnotation] == constant.EmptyString {
+		return false
+	}
+	owner := metav1.GetControllerOfNoCopy(nab)
+	return owner != nil && owner.Kind == "NonAdminSchedule" && owner.APIVersion == nacv1alpha1.GroupVersion.String()
+}
+
+// requestsCSISnapshot reports whether the BackupSpec requests volumes to be snapshotted, which, when the
+// CSI plugin is registered in the cluster, is served through CSI VolumeSnapshots regardless of
+// SnapshotMoveData (that field only controls whether the snapshot is additionally moved to object
+// storage). SnapshotVolumes defaults to true in Velero, so snapshotting is considered requested unless
+// it is explicitly disabled.
+func requestsCSISnapshot(backupSpec *velerov1.BackupSpec) bool {
+	if backupSpec == nil {
+		return false
+	}
+	if backupSpec.SnapshotMoveData != nil && *backupSpec.SnapshotMoveData {
+		return true
+	}
+	return backupSpec.SnapshotVolumes == nil || *backupSpec.SnapshotVolumes
+}
+
+// listNamespaceStorageClasses returns the distinct StorageClass names used by PersistentVolumeClaims in
+// namespace, so validateSpec can check them against the CSI snapshot VolumeSnapshotClass allow list.
+// A PVC that does not set spec.storageClassName is provisioned from the cluster's default StorageClass,
+// which this function cannot name from namespace-scoped objects alone, so it is reported as
+// constant.EmptyString; admins that need to allow the default StorageClass must add that empty key to
+// VolumeSnapshotClassAllowList.
+func (r *NonAdminBackupReconciler) listNamespaceStorageClasses(ctx context.Context, namespace string) ([]string, error) {
+	pvcList := corev1.PersistentVolumeClaimList{}
+	if err := r.List(ctx, &pvcList, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("unable to list PersistentVolumeClaims to validate CSI snapshot policy: %w", err)
+	}
+
+	seen := map[string]bool{}
+	storageClasses := make([]string, 0, len(pvcList.Items))
+	for i := range pvcList.Items {
+		storageClassName := constant.EmptyString
+		if pvcList.Items[i].Spec.StorageClassName != nil {
+			storageClassName = *pvcList.Items[i].Spec.StorageClassName
+		}
+		if seen[storageClassName] {
+			continue
+		}
+		seen[storageClassName] = true
+		storageClasses = append(storageClasses, storageClassName)
+	}
+	return storageClasses, nil
+}
+
+// syncVolumeSnapshotsStatus fetches the VolumeSnapshots created by the given, Completed VeleroBackup and
+// copies a redacted summary of each into NonAdminBackupStatus.VolumeSnapshots, so the NonAdminBackup owner
+// can see what was snapshotted without needing RBAC to read VolumeSnapshotContent objects.
+func (r *NonAdminBackupReconciler) syncVolumeSnapshotsStatus(ctx context.Context, logger logr.Logger, nab *nacv1alpha1.NonAdminBackup, veleroBackup *velerov1.Backup) (bool, error) {
+	if veleroBackup.Status.Phase != velerov1.BackupPhaseCompleted {
+		return false, nil
+	}
+
+	volumeSnapshotList := snapshotv1.VolumeSnapshotList{}
+	err := r.List(ctx, &volumeSnapshotList,
+		client.InNamespace(nab.Namespace),
+		client.MatchingLabels{velerov1.BackupNameLabel: veleroBackup.Name},
+	)
+	if err != nil {
+		logger.Error(err, "Unable to list VolumeSnapshots created by VeleroBackup")
+		return false, err
+	}
+
+	volumeSnapshots := make([]nacv1alpha1.VolumeSnapshotSummary, 0, len(volumeSnapshotList.Items))
+	for i := range volumeSnapshotList.Items {
+		volumeSnapshots = append(volumeSnapshots, r.summarizeVolumeSnapshot(ctx, logger, &volumeSnapshotList.Items[i]))
+	}
+	// List order is not guaranteed stable across reconciles; sort so unrelated reordering does not look
+	// like a status change.
+	sort.Slice(volumeSnapshots, func(i, j int) bool { return volumeSnapshots[i].Name < volumeSnapshots[j].Name })
+
+	if apiequality.Semantic.DeepEqual(nab.Status.VolumeSnapshots, volumeSnapshots) {
+		return false, nil
+	}
+
+	nab.Status.VolumeSnapshots = volumeSnapshots
+	if err := r.Status().Update(ctx, nab); err != nil {
+		logger.Error(err, statusUpdateError)
+		return false, err
+	}
+
+	logger.V(1).Info("NonAdminBackup VolumeSnapshots status updated")
 	return false, nil
 }
 
+// summarizeVolumeSnapshot redacts a VolumeSnapshot down to the fields the NonAdminBackup owner is allowed
+// to see, fetching its bound VolumeSnapshotContent to recover the storage backend's snapshotHandle.
+func (r *NonAdminBackupReconciler) summarizeVolumeSnapshot(ctx context.Context, logger logr.Logger, vs *snapshotv1.VolumeSnapshot) nacv1alpha1.VolumeSnapshotSummary {
+	summary := nacv1alpha1.VolumeSnapshotSummary{Name: vs.Name}
+	if vs.Spec.Source.PersistentVolumeClaimName != nil {
+		summary.SourcePVC = *vs.Spec.Source.PersistentVolumeClaimName
+	}
+	if vs.Status == nil {
+		return summary
+	}
+	summary.ReadyToUse = vs.Status.ReadyToUse
+	summary.RestoreSize = vs.Status.RestoreSize
+
+	if vs.Status.BoundVolumeSnapshotContentName == nil {
+		return summary
+	}
+	vsc := snapshotv1.VolumeSnapshotContent{}
+	err := r.Get(ctx, client.ObjectKey{Name: *vs.Status.BoundVolumeSnapshotContentName}, &vsc)
+	if err != nil {
+		logger.Error(err, "Unable to fetch VolumeSnapshotContent for VolumeSnapshot", "VolumeSnapshot", vs.Name)
+		return summary
+	}
+	if vsc.Status != nil && vsc.Status.SnapshotHandle != nil {
+		summary.SnapshotHandle = *vsc.Status.SnapshotHandle
+	}
+	return summary
+}
+
+// reconcileDelete cascades the deletion of a NonAdminBackup to its associated VeleroBackup.
+//
+// Parameters:
+//
+//	ctx: Context for the request.
+//	logger: Logger instance for logging messages.
+//	nab: Pointer to the NonAdminBackup object, with a non-zero DeletionTimestamp.
+//
+// The function creates a velerov1.DeleteBackupRequest targeting the referenced VeleroBackup, so its
+// object storage data is also removed, and waits for that request to reach the "Processed" phase before
+// removing the NonAdminBackupFinalizerName finalizer, allowing the NonAdminBackup to be garbage collected.
+func (r *NonAdminBackupReconciler) reconcileDelete(ctx context.Context, logger logr.Logger, nab *nacv1alpha1.NonAdminBackup) (ctrl.Result, error) {
+	logger.V(1).Info("NonAdminBackup Reconcile delete start")
+
+	if !controllerutil.ContainsFinalizer(nab, constant.NonAdminBackupFinalizerName) {
+		return ctrl.Result{}, nil
+	}
+
+	if nab.Status.VeleroBackup == nil || nab.Status.VeleroBackup.Name == constant.EmptyString {
+		logger.V(1).Info("NonAdminBackup has no associated VeleroBackup, removing finalizer")
+		return r.removeFinalizer(ctx, logger, nab)
+	}
+	veleroBackupName := nab.Status.VeleroBackup.Name
+
+	veleroBackup := velerov1.Backup{}
+	err := r.Get(ctx, client.ObjectKey{Namespace: r.OADPNamespace, Name: veleroBackupName}, &veleroBackup)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			logger.Error(err, "Unable to fetch VeleroBackup for deletion")
+			return ctrl.Result{}, err
+		}
+		logger.V(1).Info("VeleroBackup no longer exists, removing finalizer")
+		return r.removeFinalizer(ctx, logger, nab)
+	}
+
+	dbr := velerov1.DeleteBackupRequest{}
+	err = r.Get(ctx, client.ObjectKey{Namespace: r.OADPNamespace, Name: veleroBackupName}, &dbr)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			logger.Error(err, "Unable to fetch DeleteBackupRequest")
+			return ctrl.Result{}, err
+		}
+
+		if err := r.setDeletingStatus(ctx, logger, nab); err != nil {
+			return ctrl.Result{}, err
+		}
+
+		dbr = velerov1.DeleteBackupRequest{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        veleroBackupName,
+				Namespace:   r.OADPNamespace,
+				Labels:      function.GetNonAdminLabels(),
+				Annotations: function.GetNonAdminBackupAnnotations(nab.ObjectMeta),
+			},
+			Spec: velerov1.DeleteBackupRequestSpec{
+				BackupName: veleroBackupName,
+			},
+		}
+		if err := r.Create(ctx, &dbr); err != nil {
+			logger.Error(err, "Failed to create DeleteBackupRequest")
+			return ctrl.Result{}, err
+		}
+
+		logger.Info("DeleteBackupRequest created, requeuing until VeleroBackup deletion is processed")
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	if dbr.Status.Phase != velerov1.DeleteBackupRequestPhaseProcessed {
+		logger.V(1).Info("Waiting for DeleteBackupRequest to reach Processed phase", "Phase", dbr.Status.Phase)
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	// Remove the DeleteBackupRequest now that it was processed, so its deterministic name can be reused
+	// if a NonAdminBackup with the same namespace and name is later recreated and deleted again.
+	if err := r.Delete(ctx, &dbr); err != nil && !apierrors.IsNotFound(err) {
+		logger.Error(err, "Failed to delete processed DeleteBackupRequest")
+		return ctrl.Result{}, err
+	}
+
+	logger.Info("VeleroBackup deletion processed, removing finalizer")
+	return r.removeFinalizer(ctx, logger, nab)
+}
+
+// setDeletingStatus reflects that the NonAdminBackup is waiting on its VeleroBackup to be deleted.
+func (r *NonAdminBackupReconciler) setDeletingStatus(ctx context.Context, logger logr.Logger, nab *nacv1alpha1.NonAdminBackup) error {
+	updatedPhase := updateNonAdminPhase(&nab.Status.Phase, nacv1alpha1.NonAdminBackupPhaseDeleting)
+	updatedCondition := meta.SetStatusCondition(&nab.Status.Conditions,
+		metav1.Condition{
+			Type:    string(nacv1alpha1.NonAdminConditionDeleting),
+			Status:  metav1.ConditionTrue,
+			Reason:  "DeletionPending",
+			Message: "Deleting the VeleroBackup and its object storage data",
+		},
+	)
+	if updatedPhase || updatedCondition {
+		if err := r.Status().Update(ctx, nab); err != nil {
+			logger.Error(err, statusUpdateError)
+			return err
+		}
+	}
+	return nil
+}
+
+// removeFinalizer removes the NonAdminBackupFinalizerName finalizer, allowing the NonAdminBackup to be
+// garbage collected by the API server.
+func (r *NonAdminBackupReconciler) removeFinalizer(ctx context.Context, logger logr.Logger, nab *nacv1alpha1.NonAdminBackup) (ctrl.Result, error) {
+	controllerutil.RemoveFinalizer(nab, constant.NonAdminBackupFinalizerName)
+	if err := r.Update(ctx, nab); err != nil {
+		logger.Error(err, "Failed to remove finalizer from NonAdminBackup")
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *NonAdminBackupReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
@@ -279,9 +591,18 @@ func (r *NonAdminBackupReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			VeleroBackupPredicate: predicate.VeleroBackupPredicate{
 				OADPNamespace: r.OADPNamespace,
 			},
+			DeleteBackupRequestPredicate: predicate.DeleteBackupRequestPredicate{
+				OADPNamespace: r.OADPNamespace,
+			},
+			VolumeSnapshotPredicate: predicate.VolumeSnapshotPredicate{},
 		}).
 		// handler runs after predicate
 		Watches(&velerov1.Backup{}, &handler.VeleroBackupHandler{}).
+		Watches(&velerov1.DeleteBackupRequest{}, &handler.DeleteBackupRequestHandler{}).
+		Watches(&snapshotv1.VolumeSnapshot{}, &handler.VolumeSnapshotHandler{
+			Client:        r.Client,
+			OADPNamespace: r.OADPNamespace,
+		}).
 		Complete(r)
 }
 
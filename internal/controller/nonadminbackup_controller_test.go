@@ -0,0 +1,195 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	velerov1 "github.com/vmware-tanzu/velero/pkg/apis/velero/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	nacv1alpha1 "github.com/migtools/oadp-non-admin/api/v1alpha1"
+	"github.com/migtools/oadp-non-admin/internal/common/constant"
+)
+
+const testOADPNamespace = "openshift-adp"
+
+func newTestScheme(t *testing.T) *runtime.Scheme {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := nacv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatalf("unable to add nacv1alpha1 to scheme: %v", err)
+	}
+	if err := velerov1.AddToScheme(scheme); err != nil {
+		t.Fatalf("unable to add velerov1 to scheme: %v", err)
+	}
+	return scheme
+}
+
+// deletingNonAdminBackup builds a NonAdminBackup with a finalizer and a non-zero DeletionTimestamp, the
+// precondition reconcileDelete assumes.
+func deletingNonAdminBackup(name string, veleroBackup *nacv1alpha1.VeleroBackup) *nacv1alpha1.NonAdminBackup {
+	now := metav1.Now()
+	nab := &nacv1alpha1.NonAdminBackup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         "default",
+			Finalizers:        []string{constant.NonAdminBackupFinalizerName},
+			DeletionTimestamp: &now,
+		},
+	}
+	nab.Status.VeleroBackup = veleroBackup
+	return nab
+}
+
+// TestReconcileDelete_MissingVeleroBackup covers the case where the NonAdminBackup never reached a state
+// where a VeleroBackup was recorded in its Status (e.g. it was rejected at validateSpec before ever syncing).
+// reconcileDelete must remove the finalizer directly, without attempting to create a DeleteBackupRequest.
+func TestReconcileDelete_MissingVeleroBackup(t *testing.T) {
+	scheme := newTestScheme(t)
+	nab := deletingNonAdminBackup("nab-missing", nil)
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(nab).Build()
+	r := &NonAdminBackupReconciler{Client: fakeClient, Scheme: scheme, OADPNamespace: testOADPNamespace}
+
+	result, err := r.reconcileDelete(context.Background(), log.Log, nab)
+	if err != nil {
+		t.Fatalf("reconcileDelete returned error: %v", err)
+	}
+	if result.Requeue {
+		t.Fatalf("expected no requeue once finalizer is removed, got %+v", result)
+	}
+	if controllerutil.ContainsFinalizer(nab, constant.NonAdminBackupFinalizerName) {
+		t.Fatalf("expected finalizer to be removed from the in-memory object")
+	}
+
+	var dbrList velerov1.DeleteBackupRequestList
+	if err := fakeClient.List(context.Background(), &dbrList, client.InNamespace(testOADPNamespace)); err != nil {
+		t.Fatalf("unable to list DeleteBackupRequests: %v", err)
+	}
+	if len(dbrList.Items) != 0 {
+		t.Fatalf("expected no DeleteBackupRequest to be created, got %d", len(dbrList.Items))
+	}
+}
+
+// TestReconcileDelete_OrphanVeleroBackup covers the case where the NonAdminBackup's Status references a
+// VeleroBackup that no longer exists (e.g. it was deleted out-of-band by an admin). reconcileDelete must
+// treat this the same as the missing-VeleroBackup case: remove the finalizer without creating a
+// DeleteBackupRequest for an object that is already gone.
+func TestReconcileDelete_OrphanVeleroBackup(t *testing.T) {
+	scheme := newTestScheme(t)
+	nab := deletingNonAdminBackup("nab-orphan", &nacv1alpha1.VeleroBackup{
+		Name:      "nab-orphan-does-not-exist",
+		Namespace: testOADPNamespace,
+	})
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(nab).Build()
+	r := &NonAdminBackupReconciler{Client: fakeClient, Scheme: scheme, OADPNamespace: testOADPNamespace}
+
+	result, err := r.reconcileDelete(context.Background(), log.Log, nab)
+	if err != nil {
+		t.Fatalf("reconcileDelete returned error: %v", err)
+	}
+	if result.Requeue {
+		t.Fatalf("expected no requeue once finalizer is removed, got %+v", result)
+	}
+	if controllerutil.ContainsFinalizer(nab, constant.NonAdminBackupFinalizerName) {
+		t.Fatalf("expected finalizer to be removed from the in-memory object")
+	}
+}
+
+// TestReconcileDelete_CreatesDeleteBackupRequest covers the common case: the VeleroBackup still exists, so
+// reconcileDelete must create a DeleteBackupRequest targeting it and requeue rather than removing the
+// finalizer immediately.
+func TestReconcileDelete_CreatesDeleteBackupRequest(t *testing.T) {
+	scheme := newTestScheme(t)
+	veleroBackup := &velerov1.Backup{
+		ObjectMeta: metav1.ObjectMeta{Name: "nab-active-backup", Namespace: testOADPNamespace},
+	}
+	nab := deletingNonAdminBackup("nab-active", &nacv1alpha1.VeleroBackup{
+		Name:      veleroBackup.Name,
+		Namespace: testOADPNamespace,
+	})
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(nab, veleroBackup).WithStatusSubresource(nab).Build()
+	r := &NonAdminBackupReconciler{Client: fakeClient, Scheme: scheme, OADPNamespace: testOADPNamespace}
+
+	result, err := r.reconcileDelete(context.Background(), log.Log, nab)
+	if err != nil {
+		t.Fatalf("reconcileDelete returned error: %v", err)
+	}
+	if !result.Requeue {
+		t.Fatalf("expected a requeue while waiting for the DeleteBackupRequest to be processed, got %+v", result)
+	}
+	if !controllerutil.ContainsFinalizer(nab, constant.NonAdminBackupFinalizerName) {
+		t.Fatalf("expected finalizer to remain until the DeleteBackupRequest is processed")
+	}
+
+	var dbr velerov1.DeleteBackupRequest
+	err = fakeClient.Get(context.Background(), types.NamespacedName{Name: veleroBackup.Name, Namespace: testOADPNamespace}, &dbr)
+	if err != nil {
+		t.Fatalf("expected a DeleteBackupRequest to be created: %v", err)
+	}
+	if dbr.Spec.BackupName != veleroBackup.Name {
+		t.Fatalf("expected DeleteBackupRequest to target %q, got %q", veleroBackup.Name, dbr.Spec.BackupName)
+	}
+}
+
+// TestReconcileDelete_ProcessedDeleteBackupRequest covers the terminal step of the cascade: once the
+// DeleteBackupRequest reaches the Processed phase, reconcileDelete must delete it and remove the
+// finalizer.
+func TestReconcileDelete_ProcessedDeleteBackupRequest(t *testing.T) {
+	scheme := newTestScheme(t)
+	veleroBackup := &velerov1.Backup{
+		ObjectMeta: metav1.ObjectMeta{Name: "nab-processed-backup", Namespace: testOADPNamespace},
+	}
+	dbr := &velerov1.DeleteBackupRequest{
+		ObjectMeta: metav1.ObjectMeta{Name: veleroBackup.Name, Namespace: testOADPNamespace},
+		Status:     velerov1.DeleteBackupRequestStatus{Phase: velerov1.DeleteBackupRequestPhaseProcessed},
+	}
+	nab := deletingNonAdminBackup("nab-processed", &nacv1alpha1.VeleroBackup{
+		Name:      veleroBackup.Name,
+		Namespace: testOADPNamespace,
+	})
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(nab, veleroBackup, dbr).Build()
+	r := &NonAdminBackupReconciler{Client: fakeClient, Scheme: scheme, OADPNamespace: testOADPNamespace}
+
+	result, err := r.reconcileDelete(context.Background(), log.Log, nab)
+	if err != nil {
+		t.Fatalf("reconcileDelete returned error: %v", err)
+	}
+	if result.Requeue {
+		t.Fatalf("expected no requeue once the finalizer is removed, got %+v", result)
+	}
+	if controllerutil.ContainsFinalizer(nab, constant.NonAdminBackupFinalizerName) {
+		t.Fatalf("expected finalizer to be removed once DeleteBackupRequest is Processed")
+	}
+
+	err = fakeClient.Get(context.Background(), types.NamespacedName{Name: dbr.Name, Namespace: testOADPNamespace}, &velerov1.DeleteBackupRequest{})
+	if !apierrors.IsNotFound(err) {
+		t.Fatalf("expected the processed DeleteBackupRequest to be deleted, got err: %v", err)
+	}
+}
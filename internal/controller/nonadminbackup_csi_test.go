@@ -0,0 +1,291 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	snapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v7/apis/volumesnapshot/v1"
+	velerov1 "github.com/vmware-tanzu/velero/pkg/apis/velero/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	nacv1alpha1 "github.com/migtools/oadp-non-admin/api/v1alpha1"
+	"github.com/migtools/oadp-non-admin/internal/common/function"
+)
+
+func newCSITestScheme(t *testing.T) *runtime.Scheme {
+	t.Helper()
+	scheme := newTestScheme(t)
+	if err := snapshotv1.AddToScheme(scheme); err != nil {
+		t.Fatalf("unable to add snapshotv1 to scheme: %v", err)
+	}
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatalf("unable to add corev1 to scheme: %v", err)
+	}
+	return scheme
+}
+
+// TestSyncVolumeSnapshotsStatus_FakeCSIDriver simulates a CSI driver that created a VolumeSnapshot and
+// its bound VolumeSnapshotContent for a Completed VeleroBackup, and asserts that
+// syncVolumeSnapshotsStatus copies a redacted summary of it into NonAdminBackupStatus.VolumeSnapshots.
+func TestSyncVolumeSnapshotsStatus_FakeCSIDriver(t *testing.T) {
+	scheme := newCSITestScheme(t)
+
+	veleroBackup := &velerov1.Backup{
+		ObjectMeta: metav1.ObjectMeta{Name: "nab-csi-backup", Namespace: testOADPNamespace},
+		Status:     velerov1.BackupStatus{Phase: velerov1.BackupPhaseCompleted},
+	}
+
+	readyToUse := true
+	restoreSize := resource.MustParse("5Gi")
+	sourcePVC := "my-pvc"
+	vscName := "snapcontent-fake"
+	snapshotHandle := "fake-csi-driver-handle-1"
+
+	volumeSnapshot := &snapshotv1.VolumeSnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-pvc-snapshot",
+			Namespace: "default",
+			Labels:    map[string]string{velerov1.BackupNameLabel: veleroBackup.Name},
+		},
+		Spec: snapshotv1.VolumeSnapshotSpec{
+			Source: snapshotv1.VolumeSnapshotSource{PersistentVolumeClaimName: &sourcePVC},
+		},
+		Status: &snapshotv1.VolumeSnapshotStatus{
+			ReadyToUse:                     &readyToUse,
+			RestoreSize:                    &restoreSize,
+			BoundVolumeSnapshotContentName: &vscName,
+		},
+	}
+
+	volumeSnapshotContent := &snapshotv1.VolumeSnapshotContent{
+		ObjectMeta: metav1.ObjectMeta{Name: vscName},
+		Status: &snapshotv1.VolumeSnapshotContentStatus{
+			SnapshotHandle: &snapshotHandle,
+		},
+	}
+
+	nab := &nacv1alpha1.NonAdminBackup{
+		ObjectMeta: metav1.ObjectMeta{Name: "nab-csi", Namespace: "default"},
+	}
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(nab, volumeSnapshot, volumeSnapshotContent).
+		WithStatusSubresource(nab).
+		Build()
+	r := &NonAdminBackupReconciler{Client: fakeClient, Scheme: scheme, OADPNamespace: testOADPNamespace}
+
+	requeue, err := r.syncVolumeSnapshotsStatus(context.Background(), log.Log, nab, veleroBackup)
+	if err != nil {
+		t.Fatalf("syncVolumeSnapshotsStatus returned error: %v", err)
+	}
+	if requeue {
+		t.Fatalf("syncVolumeSnapshotsStatus should never request a requeue")
+	}
+
+	if len(nab.Status.VolumeSnapshots) != 1 {
+		t.Fatalf("expected 1 VolumeSnapshot summary, got %d", len(nab.Status.VolumeSnapshots))
+	}
+	summary := nab.Status.VolumeSnapshots[0]
+	if summary.Name != volumeSnapshot.Name {
+		t.Errorf("Name = %q, want %q", summary.Name, volumeSnapshot.Name)
+	}
+	if summary.SourcePVC != sourcePVC {
+		t.Errorf("SourcePVC = %q, want %q", summary.SourcePVC, sourcePVC)
+	}
+	if summary.ReadyToUse == nil || !*summary.ReadyToUse {
+		t.Errorf("ReadyToUse = %v, want true", summary.ReadyToUse)
+	}
+	if summary.RestoreSize == nil || summary.RestoreSize.Cmp(restoreSize) != 0 {
+		t.Errorf("RestoreSize = %v, want %v", summary.RestoreSize, restoreSize)
+	}
+	if summary.SnapshotHandle != snapshotHandle {
+		t.Errorf("SnapshotHandle = %q, want %q", summary.SnapshotHandle, snapshotHandle)
+	}
+}
+
+// TestSyncVolumeSnapshotsStatus_NotCompleted covers that no VolumeSnapshots are fetched or summarized
+// until the VeleroBackup itself reaches the Completed phase.
+func TestSyncVolumeSnapshotsStatus_NotCompleted(t *testing.T) {
+	scheme := newCSITestScheme(t)
+
+	veleroBackup := &velerov1.Backup{
+		ObjectMeta: metav1.ObjectMeta{Name: "nab-in-progress-backup", Namespace: testOADPNamespace},
+		Status:     velerov1.BackupStatus{Phase: velerov1.BackupPhaseInProgress},
+	}
+	nab := &nacv1alpha1.NonAdminBackup{
+		ObjectMeta: metav1.ObjectMeta{Name: "nab-in-progress", Namespace: "default"},
+	}
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(nab).WithStatusSubresource(nab).Build()
+	r := &NonAdminBackupReconciler{Client: fakeClient, Scheme: scheme, OADPNamespace: testOADPNamespace}
+
+	requeue, err := r.syncVolumeSnapshotsStatus(context.Background(), log.Log, nab, veleroBackup)
+	if err != nil {
+		t.Fatalf("syncVolumeSnapshotsStatus returned error: %v", err)
+	}
+	if requeue {
+		t.Fatalf("syncVolumeSnapshotsStatus should never request a requeue")
+	}
+	if nab.Status.VolumeSnapshots != nil {
+		t.Fatalf("expected VolumeSnapshots to remain unset, got %+v", nab.Status.VolumeSnapshots)
+	}
+}
+
+// TestValidateSpec_CSIPolicyNilVsEmptyAllowList covers the distinction between a nil
+// VolumeSnapshotClassAllowList (policy disabled) and a non-nil, empty one (policy enabled, forbidding
+// every CSI snapshot, since no StorageClass can be present in an empty map).
+func TestValidateSpec_CSIPolicyNilVsEmptyAllowList(t *testing.T) {
+	storageClassName := "gp3-csi"
+	newNAB := func() *nacv1alpha1.NonAdminBackup {
+		return &nacv1alpha1.NonAdminBackup{
+			ObjectMeta: metav1.ObjectMeta{Name: "nab-csi-policy", Namespace: "default"},
+			Spec:       nacv1alpha1.NonAdminBackupSpec{BackupSpec: &velerov1.BackupSpec{}},
+		}
+	}
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-pvc", Namespace: "default"},
+		Spec:       corev1.PersistentVolumeClaimSpec{StorageClassName: &storageClassName},
+	}
+
+	tests := []struct {
+		name         string
+		allowList    map[string]string
+		wantAccepted bool
+	}{
+		{name: "nil allow list disables the policy", allowList: nil, wantAccepted: true},
+		{name: "empty allow list forbids every CSI snapshot", allowList: map[string]string{}, wantAccepted: false},
+		{name: "populated allow list permits a listed StorageClass", allowList: map[string]string{storageClassName: "gp3-vsc"}, wantAccepted: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := newCSITestScheme(t)
+			nab := newNAB()
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(nab, pvc).WithStatusSubresource(nab).Build()
+			r := &NonAdminBackupReconciler{Client: fakeClient, Scheme: scheme, OADPNamespace: testOADPNamespace, VolumeSnapshotClassAllowList: tt.allowList}
+
+			if _, err := r.validateSpec(context.Background(), log.Log, nab); err != nil && tt.wantAccepted {
+				t.Fatalf("validateSpec returned error: %v", err)
+			}
+
+			accepted := false
+			for _, cond := range nab.Status.Conditions {
+				if cond.Type == string(nacv1alpha1.NonAdminConditionAccepted) {
+					accepted = cond.Status == metav1.ConditionTrue
+				}
+			}
+			if accepted != tt.wantAccepted {
+				t.Fatalf("Accepted condition = %v, want %v (conditions: %+v)", accepted, tt.wantAccepted, nab.Status.Conditions)
+			}
+		})
+	}
+}
+
+// TestValidateSpec_CSIPolicyExemptsAdoptedNonAdminBackups covers that a NonAdminBackup adopted from a
+// NonAdminSchedule is exempt from CSI snapshot VolumeSnapshotClass allow list enforcement, since the
+// VeleroBackup it represents - and any CSI snapshots it took - already exist by the time it is adopted.
+func TestValidateSpec_CSIPolicyExemptsAdoptedNonAdminBackups(t *testing.T) {
+	storageClassName := "gp3-csi"
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-pvc", Namespace: "default"},
+		Spec:       corev1.PersistentVolumeClaimSpec{StorageClassName: &storageClassName},
+	}
+	owningNAS := &nacv1alpha1.NonAdminSchedule{
+		ObjectMeta: metav1.ObjectMeta{Name: "nas-adopting", Namespace: "default", UID: "nas-adopting-uid"},
+	}
+	nab := &nacv1alpha1.NonAdminBackup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "nab-adopted",
+			Namespace: "default",
+			Annotations: map[string]string{
+				function.AdoptedVeleroBackupNameAnnotation: "velero-schedule-generated-20260101000000",
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(owningNAS, nacv1alpha1.GroupVersion.WithKind("NonAdminSchedule")),
+			},
+		},
+		Spec: nacv1alpha1.NonAdminBackupSpec{BackupSpec: &velerov1.BackupSpec{}},
+	}
+
+	scheme := newCSITestScheme(t)
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(nab, pvc).WithStatusSubresource(nab).Build()
+	// An allow list that would reject storageClassName if CSI policy enforcement were not skipped for
+	// adopted NonAdminBackups.
+	r := &NonAdminBackupReconciler{Client: fakeClient, Scheme: scheme, OADPNamespace: testOADPNamespace, VolumeSnapshotClassAllowList: map[string]string{}}
+
+	if _, err := r.validateSpec(context.Background(), log.Log, nab); err != nil {
+		t.Fatalf("validateSpec returned error: %v", err)
+	}
+
+	accepted := false
+	for _, cond := range nab.Status.Conditions {
+		if cond.Type == string(nacv1alpha1.NonAdminConditionAccepted) {
+			accepted = cond.Status == metav1.ConditionTrue
+		}
+	}
+	if !accepted {
+		t.Fatalf("expected adopted NonAdminBackup to be accepted despite the empty allow list, conditions: %+v", nab.Status.Conditions)
+	}
+}
+
+// TestValidateSpec_CSIPolicyRejectsForgedAdoptionAnnotation covers that AdoptedVeleroBackupNameAnnotation
+// alone does not exempt a NonAdminBackup from CSI snapshot policy enforcement: a non-admin user controls
+// every field of their own NonAdminBackup, including annotations, and setting this annotation themselves
+// without the controller OwnerReference adoptVeleroBackup sets must not bypass the policy.
+func TestValidateSpec_CSIPolicyRejectsForgedAdoptionAnnotation(t *testing.T) {
+	storageClassName := "gp3-csi"
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-pvc", Namespace: "default"},
+		Spec:       corev1.PersistentVolumeClaimSpec{StorageClassName: &storageClassName},
+	}
+	nab := &nacv1alpha1.NonAdminBackup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "nab-forged-adoption",
+			Namespace: "default",
+			Annotations: map[string]string{
+				function.AdoptedVeleroBackupNameAnnotation: "velero-schedule-generated-20260101000000",
+			},
+		},
+		Spec: nacv1alpha1.NonAdminBackupSpec{BackupSpec: &velerov1.BackupSpec{}},
+	}
+
+	scheme := newCSITestScheme(t)
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(nab, pvc).WithStatusSubresource(nab).Build()
+	r := &NonAdminBackupReconciler{Client: fakeClient, Scheme: scheme, OADPNamespace: testOADPNamespace, VolumeSnapshotClassAllowList: map[string]string{}}
+
+	if _, err := r.validateSpec(context.Background(), log.Log, nab); err == nil {
+		t.Fatalf("expected validateSpec to return an error for the unlisted StorageClass")
+	}
+
+	accepted := false
+	for _, cond := range nab.Status.Conditions {
+		if cond.Type == string(nacv1alpha1.NonAdminConditionAccepted) {
+			accepted = cond.Status == metav1.ConditionTrue
+		}
+	}
+	if accepted {
+		t.Fatalf("expected NonAdminBackup with a forged adoption annotation but no owning NonAdminSchedule to be rejected, conditions: %+v", nab.Status.Conditions)
+	}
+}
@@ -0,0 +1,429 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/go-logr/logr"
+	velerov1 "github.com/vmware-tanzu/velero/pkg/apis/velero/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	nacv1alpha1 "github.com/migtools/oadp-non-admin/api/v1alpha1"
+	"github.com/migtools/oadp-non-admin/internal/common/constant"
+	"github.com/migtools/oadp-non-admin/internal/common/function"
+	"github.com/migtools/oadp-non-admin/internal/handler"
+	"github.com/migtools/oadp-non-admin/internal/predicate"
+)
+
+// NonAdminRestoreReconciler reconciles a NonAdminRestore object
+type NonAdminRestoreReconciler struct {
+	client.Client
+	Scheme        *runtime.Scheme
+	OADPNamespace string
+}
+
+const (
+	narPhaseUpdateRequeue     = "NonAdminRestore - Requeue after Phase Update"
+	narConditionUpdateRequeue = "NonAdminRestore - Requeue after Condition Update"
+	narStatusUpdateError      = "Failed to update NonAdminRestore Status"
+)
+
+// +kubebuilder:rbac:groups=nac.oadp.openshift.io,resources=nonadminrestores,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=nac.oadp.openshift.io,resources=nonadminrestores/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=nac.oadp.openshift.io,resources=nonadminrestores/finalizers,verbs=update
+
+// +kubebuilder:rbac:groups=velero.io,resources=restores,verbs=get;list;watch;create;update;patch
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state,
+// defined in NonAdminRestore object Spec.
+func (r *NonAdminRestoreReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+	logger.V(1).Info("NonAdminRestore Reconcile start")
+
+	// Get the NonAdminRestore object
+	nar := &nacv1alpha1.NonAdminRestore{}
+	err := r.Get(ctx, req.NamespacedName, nar)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.V(1).Info(err.Error())
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "Unable to fetch NonAdminRestore")
+		return ctrl.Result{}, err
+	}
+
+	reconcileSteps := []reconcileRestoreStepFunction{
+		r.init,
+		r.validateSpec,
+		r.syncVeleroRestoreWithNonAdminRestore,
+	}
+	for _, step := range reconcileSteps {
+		requeue, err := step(ctx, logger, nar)
+		if err != nil {
+			return ctrl.Result{}, err
+		} else if requeue {
+			return ctrl.Result{Requeue: true}, nil
+		}
+	}
+	logger.V(1).Info("NonAdminRestore Reconcile exit")
+	return ctrl.Result{}, nil
+}
+
+type reconcileRestoreStepFunction func(ctx context.Context, logger logr.Logger, nar *nacv1alpha1.NonAdminRestore) (bool, error)
+
+// init initializes the Status.Phase from the NonAdminRestore.
+//
+// Parameters:
+//
+//	ctx: Context for the request.
+//	logger: Logger instance for logging messages.
+//	nar: Pointer to the NonAdminRestore object.
+//
+// The function checks if the Phase of the NonAdminRestore object is empty.
+// If it is empty, it sets the Phase to "New".
+// It then returns boolean values indicating whether the reconciliation loop should requeue or exit
+// and error value whether the status was updated successfully.
+func (r *NonAdminRestoreReconciler) init(ctx context.Context, logger logr.Logger, nar *nacv1alpha1.NonAdminRestore) (bool, error) {
+	if nar.Status.Phase == constant.EmptyString {
+		updated := updateNonAdminRestorePhase(&nar.Status.Phase, nacv1alpha1.NonAdminRestorePhaseNew)
+		if updated {
+			if err := r.Status().Update(ctx, nar); err != nil {
+				logger.Error(err, narStatusUpdateError)
+				return false, err
+			}
+
+			logger.V(1).Info(narPhaseUpdateRequeue)
+			return true, nil
+		}
+	}
+
+	logger.V(1).Info("NonAdminRestore Phase already initialized")
+	return false, nil
+}
+
+// validateSpec validates the Spec from the NonAdminRestore.
+//
+// Parameters:
+//
+//	ctx: Context for the request.
+//	logger: Logger instance for logging messages.
+//	nar: Pointer to the NonAdminRestore object.
+//
+// The function validates the RestoreSpec and confirms that the referenced NonAdminBackup exists in the
+// same namespace and has reached the "Created" phase.
+// If the RestoreSpec is invalid, the function sets the NonAdminRestore phase to "BackingOff".
+// If the referenced NonAdminBackup is not ready, the function sets the NonAdminRestore condition Accepted to
+// "False" with reason NonAdminBackupNotReady, leaves the phase unchanged and requeues.
+// If validation passes, the function sets the NonAdminRestore condition Accepted to "True".
+func (r *NonAdminRestoreReconciler) validateSpec(ctx context.Context, logger logr.Logger, nar *nacv1alpha1.NonAdminRestore) (bool, error) {
+	if err := function.ValidateRestoreSpec(nar); err != nil {
+		return r.rejectSpec(ctx, logger, nar, err, reconcile.TerminalError(err))
+	}
+
+	// The referenced NonAdminBackup may not have reached the "Created" phase yet. This is not an
+	// invalid Spec, so it is reported through a distinct Reason and the request is requeued (instead of
+	// terminally failed) until it catches up.
+	if err := r.validateNonAdminBackupReady(ctx, nar); err != nil {
+		return r.waitForNonAdminBackup(ctx, logger, nar, err)
+	}
+
+	updated := meta.SetStatusCondition(&nar.Status.Conditions,
+		metav1.Condition{
+			Type:    string(nacv1alpha1.NonAdminConditionAccepted),
+			Status:  metav1.ConditionTrue,
+			Reason:  "RestoreAccepted",
+			Message: "restore accepted",
+		},
+	)
+	if updated {
+		if err := r.Status().Update(ctx, nar); err != nil {
+			logger.Error(err, narStatusUpdateError)
+			return false, err
+		}
+
+		logger.V(1).Info(narConditionUpdateRequeue)
+		return true, nil
+	}
+
+	logger.V(1).Info("NonAdminRestore Spec already validated")
+	return false, nil
+}
+
+// rejectSpec records that the NonAdminRestore Spec itself is invalid, setting phase "BackingOff" and
+// condition Accepted=False/InvalidRestoreSpec, and returns reportErr (a reconcile.TerminalError) to the
+// caller so the reconcile loop does not keep retrying a Spec that cannot become valid on its own.
+func (r *NonAdminRestoreReconciler) rejectSpec(ctx context.Context, logger logr.Logger, nar *nacv1alpha1.NonAdminRestore, cause, reportErr error) (bool, error) {
+	updatedPhase := updateNonAdminRestorePhase(&nar.Status.Phase, nacv1alpha1.NonAdminRestorePhaseBackingOff)
+	updatedCondition := meta.SetStatusCondition(&nar.Status.Conditions,
+		metav1.Condition{
+			Type:    string(nacv1alpha1.NonAdminConditionAccepted),
+			Status:  metav1.ConditionFalse,
+			Reason:  "InvalidRestoreSpec",
+			Message: cause.Error(),
+		},
+	)
+	if updatedPhase || updatedCondition {
+		if updateErr := r.Status().Update(ctx, nar); updateErr != nil {
+			logger.Error(updateErr, narStatusUpdateError)
+			return false, updateErr
+		}
+	}
+
+	logger.Error(cause, "NonAdminRestore Spec is not valid")
+	return false, reportErr
+}
+
+// waitForNonAdminBackup records that the NonAdminRestore Spec is well-formed but is waiting on its
+// referenced NonAdminBackup to reach the "Created" phase. Unlike rejectSpec, this is a transient state:
+// the phase is left untouched (it is not BackingOff, since the Spec itself is not invalid) and the
+// condition Reason is NonAdminBackupNotReady rather than InvalidRestoreSpec. The reconcile is requeued
+// with a nil error, since there is nothing exceptional to log as an error; the request is requeued rather
+// than relying on a NonAdminBackup watch, since NonAdminBackup phase transitions do not currently fan out
+// to the NonAdminRestores that reference them.
+func (r *NonAdminRestoreReconciler) waitForNonAdminBackup(ctx context.Context, logger logr.Logger, nar *nacv1alpha1.NonAdminRestore, cause error) (bool, error) {
+	updatedCondition := meta.SetStatusCondition(&nar.Status.Conditions,
+		metav1.Condition{
+			Type:    string(nacv1alpha1.NonAdminConditionAccepted),
+			Status:  metav1.ConditionFalse,
+			Reason:  "NonAdminBackupNotReady",
+			Message: cause.Error(),
+		},
+	)
+	if updatedCondition {
+		if updateErr := r.Status().Update(ctx, nar); updateErr != nil {
+			logger.Error(updateErr, narStatusUpdateError)
+			return false, updateErr
+		}
+	}
+
+	logger.V(1).Info("NonAdminRestore waiting on referenced NonAdminBackup to become ready", "reason", cause.Error())
+	return true, nil
+}
+
+// validateNonAdminBackupReady confirms that the NonAdminBackup referenced by the NonAdminRestore exists,
+// in the same namespace, and is in the "Created" phase.
+func (r *NonAdminRestoreReconciler) validateNonAdminBackupReady(ctx context.Context, nar *nacv1alpha1.NonAdminRestore) error {
+	nab := &nacv1alpha1.NonAdminBackup{}
+	err := r.Get(ctx, client.ObjectKey{Namespace: nar.Namespace, Name: nar.Spec.NonAdminBackupName}, nab)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return fmt.Errorf("referenced NonAdminBackup %q not found in namespace %q", nar.Spec.NonAdminBackupName, nar.Namespace)
+		}
+		return err
+	}
+
+	if nab.Status.Phase != nacv1alpha1.NonAdminBackupPhaseCreated {
+		return fmt.Errorf("referenced NonAdminBackup %q is not in %q phase, current phase: %q",
+			nar.Spec.NonAdminBackupName, nacv1alpha1.NonAdminBackupPhaseCreated, nab.Status.Phase)
+	}
+
+	return nil
+}
+
+// resolveVeleroBackupName returns the name of the VeleroBackup to restore from, for the NonAdminBackup
+// referenced by the NonAdminRestore. A NonAdminBackup adopted from a NonAdminSchedule points at a
+// VeleroBackup whose name was chosen by Velero, not generated from the NonAdminBackup identity, so the
+// referenced NonAdminBackup's Status.VeleroBackup.Name - already populated, since validateSpec confirmed
+// it reached the "Created" phase - is used when present, falling back to the generated name otherwise.
+func (r *NonAdminRestoreReconciler) resolveVeleroBackupName(ctx context.Context, nar *nacv1alpha1.NonAdminRestore) (string, error) {
+	nab := &nacv1alpha1.NonAdminBackup{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: nar.Namespace, Name: nar.Spec.NonAdminBackupName}, nab); err != nil {
+		return constant.EmptyString, err
+	}
+
+	if nab.Status.VeleroBackup != nil && nab.Status.VeleroBackup.Name != constant.EmptyString {
+		return nab.Status.VeleroBackup.Name, nil
+	}
+
+	return function.GenerateVeleroBackupName(nar.Namespace, nar.Spec.NonAdminBackupName), nil
+}
+
+// syncVeleroRestoreWithNonAdminRestore ensures the VeleroRestore associated with the given NonAdminRestore resource
+// is created, if it does not exist.
+// The function also updates the status and conditions of the NonAdminRestore resource to reflect the state
+// of the VeleroRestore.
+//
+// Parameters:
+//
+//	ctx: Context for the request.
+//	logger: Logger instance for logging messages.
+//	nar: Pointer to the NonAdminRestore object.
+func (r *NonAdminRestoreReconciler) syncVeleroRestoreWithNonAdminRestore(ctx context.Context, logger logr.Logger, nar *nacv1alpha1.NonAdminRestore) (bool, error) {
+	veleroRestoreName := function.GenerateVeleroRestoreName(nar.Namespace, nar.Name)
+	if veleroRestoreName == constant.EmptyString {
+		return false, errors.New("unable to generate Velero Restore name")
+	}
+
+	veleroRestore := velerov1.Restore{}
+	veleroRestoreLogger := logger.WithValues("VeleroRestore", types.NamespacedName{Name: veleroRestoreName, Namespace: r.OADPNamespace})
+	err := r.Get(ctx, client.ObjectKey{Namespace: r.OADPNamespace, Name: veleroRestoreName}, &veleroRestore)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			veleroRestoreLogger.Error(err, "Unable to fetch VeleroRestore")
+			return false, err
+		}
+		// Create VeleroRestore
+		veleroRestoreLogger.Info("VeleroRestore not found")
+
+		veleroBackupName, err := r.resolveVeleroBackupName(ctx, nar)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				// The referenced NonAdminBackup was removed in the window since validateSpec last
+				// confirmed it was ready. Requeue rather than hard-failing; if it is gone for good,
+				// validateSpec will catch that on the next pass and report NonAdminBackupNotReady.
+				veleroRestoreLogger.V(1).Info("Referenced NonAdminBackup no longer found, requeueing", "reason", err.Error())
+				return true, nil
+			}
+			veleroRestoreLogger.Error(err, "Unable to resolve VeleroBackup name for referenced NonAdminBackup")
+			return false, err
+		}
+
+		restoreSpec := nar.Spec.RestoreSpec.DeepCopy()
+		restoreSpec.BackupName = veleroBackupName
+		restoreSpec.IncludedNamespaces = []string{nar.Namespace}
+		restoreSpec.NamespaceMapping = map[string]string{nar.Namespace: nar.Namespace}
+
+		veleroRestore = velerov1.Restore{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        veleroRestoreName,
+				Namespace:   r.OADPNamespace,
+				Labels:      function.GetNonAdminLabels(),
+				Annotations: function.GetNonAdminRestoreAnnotations(nar.ObjectMeta),
+			},
+			Spec: *restoreSpec,
+		}
+
+		err = r.Create(ctx, &veleroRestore)
+		if err != nil {
+			veleroRestoreLogger.Error(err, "Failed to create VeleroRestore")
+			return false, err
+		}
+		veleroRestoreLogger.Info("VeleroRestore successfully created")
+	}
+
+	updatedPhase := updateNonAdminRestorePhase(&nar.Status.Phase, nacv1alpha1.NonAdminRestorePhaseCreated)
+	updatedCondition := meta.SetStatusCondition(&nar.Status.Conditions,
+		metav1.Condition{
+			Type:    string(nacv1alpha1.NonAdminConditionQueued),
+			Status:  metav1.ConditionTrue,
+			Reason:  "RestoreScheduled",
+			Message: "Created Velero Restore object",
+		},
+	)
+	updatedReference := updateNonAdminRestoreVeleroRestoreReference(&nar.Status, &veleroRestore)
+	if updatedPhase || updatedCondition || updatedReference {
+		if err := r.Status().Update(ctx, nar); err != nil {
+			logger.Error(err, narStatusUpdateError)
+			return false, err
+		}
+
+		logger.V(1).Info("NonAdminRestore - Exit after Status Update")
+		return false, nil
+	}
+
+	// Ensure that the NonAdminRestore's NonAdminRestoreStatus is in sync
+	// with the VeleroRestore. Any required updates to the NonAdminRestore
+	// Status will be applied based on the current state of the VeleroRestore.
+	veleroRestoreLogger.Info("VeleroRestore already exists, verifying if NonAdminRestore Status requires update")
+	updated := updateNonAdminRestoreVeleroRestoreStatus(&nar.Status, &veleroRestore)
+	if updated {
+		if err := r.Status().Update(ctx, nar); err != nil {
+			veleroRestoreLogger.Error(err, "Failed to update NonAdminRestore Status after VeleroRestore reconciliation")
+			return false, err
+		}
+
+		logger.V(1).Info("NonAdminRestore Status updated successfully")
+	}
+
+	return false, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *NonAdminRestoreReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&nacv1alpha1.NonAdminRestore{}).
+		WithEventFilter(predicate.RestoreCompositePredicate{
+			NonAdminRestorePredicate: predicate.NonAdminRestorePredicate{},
+			VeleroRestorePredicate: predicate.VeleroRestorePredicate{
+				OADPNamespace: r.OADPNamespace,
+			},
+		}).
+		// handler runs after predicate
+		Watches(&velerov1.Restore{}, &handler.VeleroRestoreHandler{}).
+		Complete(r)
+}
+
+// updateNonAdminRestorePhase sets the phase in NonAdminRestore object status and returns true
+// if the phase is changed by this call.
+func updateNonAdminRestorePhase(phase *nacv1alpha1.NonAdminRestorePhase, newPhase nacv1alpha1.NonAdminRestorePhase) bool {
+	// Ensure phase is valid
+	if newPhase == constant.EmptyString {
+		return false
+	}
+
+	if *phase == newPhase {
+		return false
+	}
+
+	*phase = newPhase
+	return true
+}
+
+// updateNonAdminRestoreVeleroRestoreReference sets the VeleroRestore reference fields in NonAdminRestore object
+// status and returns true if the VeleroRestore fields are changed by this call.
+func updateNonAdminRestoreVeleroRestoreReference(status *nacv1alpha1.NonAdminRestoreStatus, veleroRestore *velerov1.Restore) bool {
+	if status.VeleroRestore == nil {
+		status.VeleroRestore = &nacv1alpha1.VeleroRestore{
+			Name:      veleroRestore.Name,
+			Namespace: veleroRestore.Namespace,
+		}
+		return true
+	} else if status.VeleroRestore.Name != veleroRestore.Name || status.VeleroRestore.Namespace != veleroRestore.Namespace {
+		status.VeleroRestore.Name = veleroRestore.Name
+		status.VeleroRestore.Namespace = veleroRestore.Namespace
+		return true
+	}
+	return false
+}
+
+// updateNonAdminRestoreVeleroRestoreStatus sets the VeleroRestore status field in NonAdminRestore object status
+// and returns true if the VeleroRestore fields are changed by this call.
+func updateNonAdminRestoreVeleroRestoreStatus(status *nacv1alpha1.NonAdminRestoreStatus, veleroRestore *velerov1.Restore) bool {
+	if status.VeleroRestore == nil {
+		status.VeleroRestore = &nacv1alpha1.VeleroRestore{
+			Status: veleroRestore.Status.DeepCopy(),
+		}
+		return true
+	} else if !reflect.DeepEqual(status.VeleroRestore.Status, &veleroRestore.Status) {
+		status.VeleroRestore.Status = veleroRestore.Status.DeepCopy()
+		return true
+	}
+	return false
+}
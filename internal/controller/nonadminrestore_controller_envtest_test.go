@@ -0,0 +1,213 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	velerov1 "github.com/vmware-tanzu/velero/pkg/apis/velero/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	nacv1alpha1 "github.com/migtools/oadp-non-admin/api/v1alpha1"
+	"github.com/migtools/oadp-non-admin/internal/common/function"
+)
+
+const envtestOADPNamespace = "openshift-adp"
+
+// createTestNamespace creates a uniquely-named Namespace for a single test and returns its name.
+func createTestNamespace(prefix string) string {
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{GenerateName: prefix}}
+	ExpectWithOffset(1, k8sClient.Create(ctx, ns)).To(Succeed())
+	return ns.Name
+}
+
+// createdNonAdminBackup creates a NonAdminBackup in namespace that is already in the "Created" phase, as
+// if the NonAdminBackup controller had already reconciled it to completion.
+func createdNonAdminBackup(namespace, name string) *nacv1alpha1.NonAdminBackup {
+	nab := &nacv1alpha1.NonAdminBackup{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec:       nacv1alpha1.NonAdminBackupSpec{BackupSpec: &velerov1.BackupSpec{}},
+	}
+	ExpectWithOffset(1, k8sClient.Create(ctx, nab)).To(Succeed())
+	nab.Status.Phase = nacv1alpha1.NonAdminBackupPhaseCreated
+	ExpectWithOffset(1, k8sClient.Status().Update(ctx, nab)).To(Succeed())
+	return nab
+}
+
+var _ = Describe("NonAdminRestore controller", func() {
+	var namespace string
+	var reconciler *NonAdminRestoreReconciler
+
+	BeforeEach(func() {
+		namespace = createTestNamespace("nar-test-")
+		reconciler = &NonAdminRestoreReconciler{Client: k8sClient, Scheme: k8sClient.Scheme(), OADPNamespace: envtestOADPNamespace}
+	})
+
+	reconcileUntilSettled := func(req ctrl.Request) {
+		// The reconcile loop processes one step per call (Status Update requeues). A handful of
+		// passes is enough to drive it to a fixed point for these tests.
+		for i := 0; i < 5; i++ {
+			_, err := reconciler.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+		}
+	}
+
+	When("the NonAdminRestore is accepted", func() {
+		It("creates a VeleroRestore in the OADP namespace with IncludedNamespaces and NamespaceMapping forced to the NonAdminRestore namespace", func() {
+			nab := createdNonAdminBackup(namespace, "nar-src-backup")
+
+			nar := &nacv1alpha1.NonAdminRestore{
+				ObjectMeta: metav1.ObjectMeta{Name: "nar-accepted", Namespace: namespace},
+				Spec: nacv1alpha1.NonAdminRestoreSpec{
+					NonAdminBackupName: nab.Name,
+					RestoreSpec:        &velerov1.RestoreSpec{},
+				},
+			}
+			Expect(k8sClient.Create(ctx, nar)).To(Succeed())
+
+			reconcileUntilSettled(ctrl.Request{NamespacedName: types.NamespacedName{Name: nar.Name, Namespace: namespace}})
+
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: nar.Name, Namespace: namespace}, nar)).To(Succeed())
+			Expect(nar.Status.Phase).To(Equal(nacv1alpha1.NonAdminRestorePhaseCreated))
+			Expect(nar.Status.VeleroRestore).NotTo(BeNil())
+
+			veleroRestore := &velerov1.Restore{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: nar.Status.VeleroRestore.Name, Namespace: envtestOADPNamespace}, veleroRestore)).To(Succeed())
+			Expect(veleroRestore.Spec.IncludedNamespaces).To(Equal([]string{namespace}))
+			Expect(veleroRestore.Spec.NamespaceMapping).To(Equal(map[string]string{namespace: namespace}))
+		})
+
+		It("restores from the real VeleroBackup name recorded in Status.VeleroBackup.Name, not a generated one, for a schedule-adopted NonAdminBackup", func() {
+			nab := &nacv1alpha1.NonAdminBackup{
+				ObjectMeta: metav1.ObjectMeta{Name: "adopted-nab", Namespace: namespace},
+				Spec:       nacv1alpha1.NonAdminBackupSpec{BackupSpec: &velerov1.BackupSpec{}},
+			}
+			Expect(k8sClient.Create(ctx, nab)).To(Succeed())
+			nab.Status.Phase = nacv1alpha1.NonAdminBackupPhaseCreated
+			nab.Status.VeleroBackup = &nacv1alpha1.VeleroBackup{
+				// A Velero-chosen name, unrelated to what function.GenerateVeleroBackupName would produce.
+				Name:      "velero-schedule-generated-20260101000000",
+				Namespace: envtestOADPNamespace,
+			}
+			Expect(k8sClient.Status().Update(ctx, nab)).To(Succeed())
+
+			nar := &nacv1alpha1.NonAdminRestore{
+				ObjectMeta: metav1.ObjectMeta{Name: "nar-from-adopted", Namespace: namespace},
+				Spec: nacv1alpha1.NonAdminRestoreSpec{
+					NonAdminBackupName: nab.Name,
+					RestoreSpec:        &velerov1.RestoreSpec{},
+				},
+			}
+			Expect(k8sClient.Create(ctx, nar)).To(Succeed())
+
+			reconcileUntilSettled(ctrl.Request{NamespacedName: types.NamespacedName{Name: nar.Name, Namespace: namespace}})
+
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: nar.Name, Namespace: namespace}, nar)).To(Succeed())
+			Expect(nar.Status.VeleroRestore).NotTo(BeNil())
+
+			veleroRestore := &velerov1.Restore{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: nar.Status.VeleroRestore.Name, Namespace: envtestOADPNamespace}, veleroRestore)).To(Succeed())
+			Expect(veleroRestore.Spec.BackupName).To(Equal(nab.Status.VeleroBackup.Name))
+		})
+	})
+
+	When("the NonAdminRestore references a NonAdminBackup in another namespace", func() {
+		It("never creates a VeleroRestore, reporting NonAdminBackupNotReady instead of a terminal rejection", func() {
+			otherNamespace := createTestNamespace("nar-other-")
+			createdNonAdminBackup(otherNamespace, "cross-ns-backup")
+
+			nar := &nacv1alpha1.NonAdminRestore{
+				ObjectMeta: metav1.ObjectMeta{Name: "nar-cross-ns", Namespace: namespace},
+				Spec: nacv1alpha1.NonAdminRestoreSpec{
+					// The referenced NonAdminBackup only exists in otherNamespace: NonAdminRestore
+					// has no field to name a different namespace, so this can never resolve.
+					NonAdminBackupName: "cross-ns-backup",
+					RestoreSpec:        &velerov1.RestoreSpec{},
+				},
+			}
+			Expect(k8sClient.Create(ctx, nar)).To(Succeed())
+
+			reconcileUntilSettled(ctrl.Request{NamespacedName: types.NamespacedName{Name: nar.Name, Namespace: namespace}})
+
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: nar.Name, Namespace: namespace}, nar)).To(Succeed())
+			Expect(nar.Status.Phase).NotTo(Equal(nacv1alpha1.NonAdminRestorePhaseBackingOff))
+			acceptedCondition := meta.FindStatusCondition(nar.Status.Conditions, string(nacv1alpha1.NonAdminConditionAccepted))
+			Expect(acceptedCondition).NotTo(BeNil())
+			Expect(acceptedCondition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(acceptedCondition.Reason).To(Equal("NonAdminBackupNotReady"))
+			Expect(nar.Status.VeleroRestore).To(BeNil())
+
+			// Other specs share the apiserver, so only VeleroRestores originated from this namespace count.
+			var veleroRestores velerov1.RestoreList
+			Expect(k8sClient.List(ctx, &veleroRestores)).To(Succeed())
+			fromThisNamespace := []string{}
+			for _, veleroRestore := range veleroRestores.Items {
+				if veleroRestore.Annotations[function.NarOriginNamespaceAnnotation] == namespace {
+					fromThisNamespace = append(fromThisNamespace, veleroRestore.Name)
+				}
+			}
+			Expect(fromThisNamespace).To(BeEmpty())
+		})
+	})
+
+	When("the referenced NonAdminBackup has not reached the Created phase yet", func() {
+		It("reports NonAdminBackupNotReady and keeps requeuing instead of backing off", func() {
+			nab := &nacv1alpha1.NonAdminBackup{
+				ObjectMeta: metav1.ObjectMeta{Name: "nar-pending-backup", Namespace: namespace},
+				Spec:       nacv1alpha1.NonAdminBackupSpec{BackupSpec: &velerov1.BackupSpec{}},
+			}
+			Expect(k8sClient.Create(ctx, nab)).To(Succeed())
+			// Left with an empty phase: the NonAdminBackup controller has not reconciled it yet.
+
+			nar := &nacv1alpha1.NonAdminRestore{
+				ObjectMeta: metav1.ObjectMeta{Name: "nar-waiting", Namespace: namespace},
+				Spec: nacv1alpha1.NonAdminRestoreSpec{
+					NonAdminBackupName: nab.Name,
+					RestoreSpec:        &velerov1.RestoreSpec{},
+				},
+			}
+			Expect(k8sClient.Create(ctx, nar)).To(Succeed())
+
+			req := ctrl.Request{NamespacedName: types.NamespacedName{Name: nar.Name, Namespace: namespace}}
+			reconcileUntilSettled(req)
+
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: nar.Name, Namespace: namespace}, nar)).To(Succeed())
+			Expect(nar.Status.Phase).NotTo(Equal(nacv1alpha1.NonAdminRestorePhaseBackingOff))
+			acceptedCondition := meta.FindStatusCondition(nar.Status.Conditions, string(nacv1alpha1.NonAdminConditionAccepted))
+			Expect(acceptedCondition).NotTo(BeNil())
+			Expect(acceptedCondition.Reason).To(Equal("NonAdminBackupNotReady"))
+
+			// Once the referenced NonAdminBackup becomes ready, a further reconcile (as would be
+			// triggered by the requeue) accepts the NonAdminRestore and creates the VeleroRestore.
+			nab.Status.Phase = nacv1alpha1.NonAdminBackupPhaseCreated
+			Expect(k8sClient.Status().Update(ctx, nab)).To(Succeed())
+			reconcileUntilSettled(req)
+
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: nar.Name, Namespace: namespace}, nar)).To(Succeed())
+			Expect(nar.Status.Phase).To(Equal(nacv1alpha1.NonAdminRestorePhaseCreated))
+			Expect(nar.Status.VeleroRestore).NotTo(BeNil())
+
+			veleroRestore := &velerov1.Restore{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: nar.Status.VeleroRestore.Name, Namespace: envtestOADPNamespace}, veleroRestore)).To(Succeed())
+		})
+	})
+})
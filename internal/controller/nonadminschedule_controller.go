@@ -0,0 +1,464 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/go-logr/logr"
+	velerov1 "github.com/vmware-tanzu/velero/pkg/apis/velero/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	nacv1alpha1 "github.com/migtools/oadp-non-admin/api/v1alpha1"
+	"github.com/migtools/oadp-non-admin/internal/common/constant"
+	"github.com/migtools/oadp-non-admin/internal/common/function"
+	"github.com/migtools/oadp-non-admin/internal/handler"
+	"github.com/migtools/oadp-non-admin/internal/predicate"
+)
+
+// NonAdminScheduleReconciler reconciles a NonAdminSchedule object
+type NonAdminScheduleReconciler struct {
+	client.Client
+	Scheme        *runtime.Scheme
+	OADPNamespace string
+	// MinScheduleCronInterval is the minimum interval an admin allows a NonAdminSchedule cron expression to
+	// run at. Cron expressions that would fire more frequently than this are rejected during validation.
+	MinScheduleCronInterval time.Duration
+}
+
+type reconcileScheduleStepFunction func(ctx context.Context, logger logr.Logger, nas *nacv1alpha1.NonAdminSchedule) (bool, error)
+
+const (
+	nasPhaseUpdateRequeue     = "NonAdminSchedule - Requeue after Phase Update"
+	nasConditionUpdateRequeue = "NonAdminSchedule - Requeue after Condition Update"
+	nasStatusUpdateError      = "Failed to update NonAdminSchedule Status"
+)
+
+// +kubebuilder:rbac:groups=nac.oadp.openshift.io,resources=nonadminschedules,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=nac.oadp.openshift.io,resources=nonadminschedules/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=nac.oadp.openshift.io,resources=nonadminschedules/finalizers,verbs=update
+
+// +kubebuilder:rbac:groups=velero.io,resources=schedules,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups=velero.io,resources=backups,verbs=get;list;watch;create;update;patch
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state,
+// defined in NonAdminSchedule object Spec.
+func (r *NonAdminScheduleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+	logger.V(1).Info("NonAdminSchedule Reconcile start")
+
+	// Get the NonAdminSchedule object
+	nas := &nacv1alpha1.NonAdminSchedule{}
+	err := r.Get(ctx, req.NamespacedName, nas)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.V(1).Info(err.Error())
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "Unable to fetch NonAdminSchedule")
+		return ctrl.Result{}, err
+	}
+
+	reconcileSteps := []reconcileScheduleStepFunction{
+		r.init,
+		r.validateSpec,
+		r.syncVeleroScheduleWithNonAdminSchedule,
+		r.adoptVeleroBackups,
+	}
+	for _, step := range reconcileSteps {
+		requeue, err := step(ctx, logger, nas)
+		if err != nil {
+			return ctrl.Result{}, err
+		} else if requeue {
+			return ctrl.Result{Requeue: true}, nil
+		}
+	}
+	logger.V(1).Info("NonAdminSchedule Reconcile exit")
+	return ctrl.Result{}, nil
+}
+
+// init initializes the Status.Phase from the NonAdminSchedule.
+//
+// Parameters:
+//
+//	ctx: Context for the request.
+//	logger: Logger instance for logging messages.
+//	nas: Pointer to the NonAdminSchedule object.
+//
+// The function checks if the Phase of the NonAdminSchedule object is empty.
+// If it is empty, it sets the Phase to "New".
+// It then returns boolean values indicating whether the reconciliation loop should requeue or exit
+// and error value whether the status was updated successfully.
+func (r *NonAdminScheduleReconciler) init(ctx context.Context, logger logr.Logger, nas *nacv1alpha1.NonAdminSchedule) (bool, error) {
+	if nas.Status.Phase == constant.EmptyString {
+		updated := updateNonAdminSchedulePhase(&nas.Status.Phase, nacv1alpha1.NonAdminSchedulePhaseNew)
+		if updated {
+			if err := r.Status().Update(ctx, nas); err != nil {
+				logger.Error(err, nasStatusUpdateError)
+				return false, err
+			}
+
+			logger.V(1).Info(nasPhaseUpdateRequeue)
+			return true, nil
+		}
+	}
+
+	logger.V(1).Info("NonAdminSchedule Phase already initialized")
+	return false, nil
+}
+
+// validateSpec validates the Spec from the NonAdminSchedule.
+//
+// Parameters:
+//
+//	ctx: Context for the request.
+//	logger: Logger instance for logging messages.
+//	nas: Pointer to the NonAdminSchedule object.
+//
+// The function validates the Spec from the NonAdminSchedule object, including that the cron expression
+// does not run more frequently than MinScheduleCronInterval.
+// If the ScheduleSpec is invalid, the function sets the NonAdminSchedule phase to "BackingOff".
+// If the ScheduleSpec is invalid, the function sets the NonAdminSchedule condition Accepted to "False".
+// If the ScheduleSpec is valid, the function sets the NonAdminSchedule condition Accepted to "True".
+func (r *NonAdminScheduleReconciler) validateSpec(ctx context.Context, logger logr.Logger, nas *nacv1alpha1.NonAdminSchedule) (bool, error) {
+	err := function.ValidateScheduleSpec(nas, r.MinScheduleCronInterval)
+	if err != nil {
+		updatedPhase := updateNonAdminSchedulePhase(&nas.Status.Phase, nacv1alpha1.NonAdminSchedulePhaseBackingOff)
+		updatedCondition := meta.SetStatusCondition(&nas.Status.Conditions,
+			metav1.Condition{
+				Type:    string(nacv1alpha1.NonAdminConditionAccepted),
+				Status:  metav1.ConditionFalse,
+				Reason:  "InvalidScheduleSpec",
+				Message: err.Error(),
+			},
+		)
+		if updatedPhase || updatedCondition {
+			if updateErr := r.Status().Update(ctx, nas); updateErr != nil {
+				logger.Error(updateErr, nasStatusUpdateError)
+				return false, updateErr
+			}
+		}
+
+		logger.Error(err, "NonAdminSchedule Spec is not valid")
+		return false, reconcile.TerminalError(err)
+	}
+
+	updated := meta.SetStatusCondition(&nas.Status.Conditions,
+		metav1.Condition{
+			Type:    string(nacv1alpha1.NonAdminConditionAccepted),
+			Status:  metav1.ConditionTrue,
+			Reason:  "ScheduleAccepted",
+			Message: "schedule accepted",
+		},
+	)
+	if updated {
+		if err := r.Status().Update(ctx, nas); err != nil {
+			logger.Error(err, nasStatusUpdateError)
+			return false, err
+		}
+
+		logger.V(1).Info(nasConditionUpdateRequeue)
+		return true, nil
+	}
+
+	logger.V(1).Info("NonAdminSchedule Spec already validated")
+	return false, nil
+}
+
+// syncVeleroScheduleWithNonAdminSchedule ensures the VeleroSchedule associated with the given NonAdminSchedule
+// resource is created, if it does not exist, and that its Template.IncludedNamespaces stays confined to the
+// NonAdminSchedule namespace even if the Spec drifts.
+// The function also updates the status and conditions of the NonAdminSchedule resource to reflect the state
+// of the VeleroSchedule.
+//
+// Parameters:
+//
+//	ctx: Context for the request.
+//	logger: Logger instance for logging messages.
+//	nas: Pointer to the NonAdminSchedule object.
+func (r *NonAdminScheduleReconciler) syncVeleroScheduleWithNonAdminSchedule(ctx context.Context, logger logr.Logger, nas *nacv1alpha1.NonAdminSchedule) (bool, error) {
+	veleroScheduleName := function.GenerateVeleroScheduleName(nas.Namespace, nas.Name)
+	if veleroScheduleName == constant.EmptyString {
+		return false, errors.New("unable to generate Velero Schedule name")
+	}
+
+	veleroSchedule := velerov1.Schedule{}
+	veleroScheduleLogger := logger.WithValues("VeleroSchedule", types.NamespacedName{Name: veleroScheduleName, Namespace: r.OADPNamespace})
+	err := r.Get(ctx, client.ObjectKey{Namespace: r.OADPNamespace, Name: veleroScheduleName}, &veleroSchedule)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			veleroScheduleLogger.Error(err, "Unable to fetch VeleroSchedule")
+			return false, err
+		}
+		// Create VeleroSchedule
+		veleroScheduleLogger.Info("VeleroSchedule not found")
+
+		scheduleSpec := nas.Spec.ScheduleSpec.DeepCopy()
+		scheduleSpec.Template.IncludedNamespaces = []string{nas.Namespace}
+
+		veleroSchedule = velerov1.Schedule{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        veleroScheduleName,
+				Namespace:   r.OADPNamespace,
+				Labels:      function.GetNonAdminLabels(),
+				Annotations: function.GetNonAdminScheduleAnnotations(nas.ObjectMeta),
+			},
+			Spec: *scheduleSpec,
+		}
+
+		err = r.Create(ctx, &veleroSchedule)
+		if err != nil {
+			veleroScheduleLogger.Error(err, "Failed to create VeleroSchedule")
+			return false, err
+		}
+		veleroScheduleLogger.Info("VeleroSchedule successfully created")
+	} else if len(veleroSchedule.Spec.Template.IncludedNamespaces) != 1 ||
+		veleroSchedule.Spec.Template.IncludedNamespaces[0] != nas.Namespace {
+		// Correct spec drift: the VeleroSchedule must always be confined to the NonAdminSchedule namespace
+		veleroSchedule.Spec.Template.IncludedNamespaces = []string{nas.Namespace}
+		if err := r.Update(ctx, &veleroSchedule); err != nil {
+			veleroScheduleLogger.Error(err, "Failed to correct VeleroSchedule Template.IncludedNamespaces drift")
+			return false, err
+		}
+		veleroScheduleLogger.Info("VeleroSchedule Template.IncludedNamespaces drift corrected")
+	}
+
+	updatedPhase := updateNonAdminSchedulePhase(&nas.Status.Phase, nacv1alpha1.NonAdminSchedulePhaseEnabled)
+	updatedCondition := meta.SetStatusCondition(&nas.Status.Conditions,
+		metav1.Condition{
+			Type:    string(nacv1alpha1.NonAdminConditionQueued),
+			Status:  metav1.ConditionTrue,
+			Reason:  "ScheduleCreated",
+			Message: "Created Velero Schedule object",
+		},
+	)
+	updatedReference := updateNonAdminScheduleVeleroScheduleReference(&nas.Status, &veleroSchedule)
+	if updatedPhase || updatedCondition || updatedReference {
+		if err := r.Status().Update(ctx, nas); err != nil {
+			logger.Error(err, nasStatusUpdateError)
+			return false, err
+		}
+
+		logger.V(1).Info("NonAdminSchedule - Exit after Status Update")
+		return false, nil
+	}
+
+	// Ensure that the NonAdminSchedule's NonAdminScheduleStatus is in sync
+	// with the VeleroSchedule. Any required updates to the NonAdminSchedule
+	// Status will be applied based on the current state of the VeleroSchedule.
+	veleroScheduleLogger.Info("VeleroSchedule already exists, verifying if NonAdminSchedule Status requires update")
+	updated := updateNonAdminScheduleVeleroScheduleStatus(&nas.Status, &veleroSchedule)
+	if updated {
+		if err := r.Status().Update(ctx, nas); err != nil {
+			veleroScheduleLogger.Error(err, "Failed to update NonAdminSchedule Status after VeleroSchedule reconciliation")
+			return false, err
+		}
+
+		logger.V(1).Info("NonAdminSchedule Status updated successfully")
+	}
+
+	return false, nil
+}
+
+// adoptVeleroBackups finds Backups created by the VeleroSchedule that are not yet represented as
+// NonAdminBackups, and adopts them - one at a time - by creating a NonAdminBackup, owned by the
+// NonAdminSchedule, with its Status.VeleroBackup already pointing at the existing VeleroBackup. This lets
+// users see and restore schedule-created backups through the regular NonAdminBackup API.
+//
+// Parameters:
+//
+//	ctx: Context for the request.
+//	logger: Logger instance for logging messages.
+//	nas: Pointer to the NonAdminSchedule object.
+func (r *NonAdminScheduleReconciler) adoptVeleroBackups(ctx context.Context, logger logr.Logger, nas *nacv1alpha1.NonAdminSchedule) (bool, error) {
+	if nas.Status.VeleroSchedule == nil || nas.Status.VeleroSchedule.Name == constant.EmptyString {
+		return false, nil
+	}
+
+	veleroBackupList := velerov1.BackupList{}
+	err := r.List(ctx, &veleroBackupList,
+		client.InNamespace(r.OADPNamespace),
+		client.MatchingLabels{velerov1.ScheduleNameLabel: nas.Status.VeleroSchedule.Name},
+	)
+	if err != nil {
+		logger.Error(err, "Unable to list VeleroBackups created by VeleroSchedule")
+		return false, err
+	}
+
+	for i := range veleroBackupList.Items {
+		veleroBackup := &veleroBackupList.Items[i]
+		if _, adopted := veleroBackup.Annotations[function.NabOriginNameAnnotation]; adopted {
+			continue
+		}
+
+		if err := r.adoptVeleroBackup(ctx, logger, nas, veleroBackup); err != nil {
+			return false, err
+		}
+
+		// Requeue to adopt the remaining Backups, one reconcile at a time, keeping each step simple
+		// to reason about and to retry.
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// adoptVeleroBackup creates the NonAdminBackup representing a single VeleroBackup created by the
+// VeleroSchedule, and annotates the VeleroBackup so it is not adopted again.
+func (r *NonAdminScheduleReconciler) adoptVeleroBackup(ctx context.Context, logger logr.Logger, nas *nacv1alpha1.NonAdminSchedule, veleroBackup *velerov1.Backup) error {
+	nabLogger := logger.WithValues("VeleroBackup", types.NamespacedName{Name: veleroBackup.Name, Namespace: veleroBackup.Namespace})
+
+	nab := nacv1alpha1.NonAdminBackup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      veleroBackup.Name,
+			Namespace: nas.Namespace,
+			Labels:    function.GetNonAdminLabels(),
+			Annotations: map[string]string{
+				function.AdoptedVeleroBackupNameAnnotation: veleroBackup.Name,
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(nas, nacv1alpha1.GroupVersion.WithKind("NonAdminSchedule")),
+			},
+		},
+		Spec: nacv1alpha1.NonAdminBackupSpec{
+			BackupSpec: nas.Spec.ScheduleSpec.Template.DeepCopy(),
+		},
+	}
+	if err := r.Create(ctx, &nab); err != nil {
+		if !apierrors.IsAlreadyExists(err) {
+			nabLogger.Error(err, "Failed to create adopted NonAdminBackup")
+			return err
+		}
+		// A previous reconcile already created this NonAdminBackup but failed before finishing the
+		// Status and VeleroBackup annotation steps below. Fetch it and resume completing the
+		// adoption instead of erroring out forever on the same AlreadyExists.
+		nabLogger.Info("Adopted NonAdminBackup already exists, resuming adoption")
+		if err := r.Get(ctx, client.ObjectKey{Name: nab.Name, Namespace: nab.Namespace}, &nab); err != nil {
+			nabLogger.Error(err, "Failed to fetch existing adopted NonAdminBackup")
+			return err
+		}
+		// The VeleroBackup name is only reused across adoptions of the same VeleroBackup (Velero
+		// names are unique within the OADP namespace), but guard against a same-named NonAdminBackup
+		// that was not created by this adoption before overwriting its Status.
+		if nab.Annotations[function.AdoptedVeleroBackupNameAnnotation] != veleroBackup.Name {
+			err := fmt.Errorf("NonAdminBackup %q already exists and was not adopted from VeleroBackup %q", nab.Name, veleroBackup.Name)
+			nabLogger.Error(err, "Refusing to overwrite unrelated NonAdminBackup")
+			return err
+		}
+	}
+
+	nab.Status.Phase = nacv1alpha1.NonAdminBackupPhaseCreated
+	nab.Status.VeleroBackup = &nacv1alpha1.VeleroBackup{
+		Name:      veleroBackup.Name,
+		Namespace: veleroBackup.Namespace,
+		Status:    veleroBackup.Status.DeepCopy(),
+	}
+	if err := r.Status().Update(ctx, &nab); err != nil {
+		nabLogger.Error(err, "Failed to set adopted NonAdminBackup Status")
+		return err
+	}
+
+	if veleroBackup.Annotations == nil {
+		veleroBackup.Annotations = map[string]string{}
+	}
+	for key, value := range function.GetNonAdminBackupAnnotations(nab.ObjectMeta) {
+		veleroBackup.Annotations[key] = value
+	}
+	if err := r.Update(ctx, veleroBackup); err != nil {
+		nabLogger.Error(err, "Failed to annotate adopted VeleroBackup")
+		return err
+	}
+
+	nabLogger.Info("Adopted VeleroBackup as NonAdminBackup", "NonAdminBackup", nab.Name)
+	return nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *NonAdminScheduleReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&nacv1alpha1.NonAdminSchedule{}).
+		WithEventFilter(predicate.ScheduleCompositePredicate{
+			NonAdminSchedulePredicate: predicate.NonAdminSchedulePredicate{},
+			VeleroSchedulePredicate: predicate.VeleroSchedulePredicate{
+				OADPNamespace: r.OADPNamespace,
+			},
+		}).
+		// handler runs after predicate
+		Watches(&velerov1.Schedule{}, &handler.VeleroScheduleHandler{}).
+		Complete(r)
+}
+
+// updateNonAdminSchedulePhase sets the phase in NonAdminSchedule object status and returns true
+// if the phase is changed by this call.
+func updateNonAdminSchedulePhase(phase *nacv1alpha1.NonAdminSchedulePhase, newPhase nacv1alpha1.NonAdminSchedulePhase) bool {
+	// Ensure phase is valid
+	if newPhase == constant.EmptyString {
+		return false
+	}
+
+	if *phase == newPhase {
+		return false
+	}
+
+	*phase = newPhase
+	return true
+}
+
+// updateNonAdminScheduleVeleroScheduleReference sets the VeleroSchedule reference fields in NonAdminSchedule
+// object status and returns true if the VeleroSchedule fields are changed by this call.
+func updateNonAdminScheduleVeleroScheduleReference(status *nacv1alpha1.NonAdminScheduleStatus, veleroSchedule *velerov1.Schedule) bool {
+	if status.VeleroSchedule == nil {
+		status.VeleroSchedule = &nacv1alpha1.VeleroSchedule{
+			Name:      veleroSchedule.Name,
+			Namespace: veleroSchedule.Namespace,
+		}
+		return true
+	} else if status.VeleroSchedule.Name != veleroSchedule.Name || status.VeleroSchedule.Namespace != veleroSchedule.Namespace {
+		status.VeleroSchedule.Name = veleroSchedule.Name
+		status.VeleroSchedule.Namespace = veleroSchedule.Namespace
+		return true
+	}
+	return false
+}
+
+// updateNonAdminScheduleVeleroScheduleStatus sets the VeleroSchedule status field in NonAdminSchedule object
+// status and returns true if the VeleroSchedule fields are changed by this call.
+func updateNonAdminScheduleVeleroScheduleStatus(status *nacv1alpha1.NonAdminScheduleStatus, veleroSchedule *velerov1.Schedule) bool {
+	if status.VeleroSchedule == nil {
+		status.VeleroSchedule = &nacv1alpha1.VeleroSchedule{
+			Status: veleroSchedule.Status.DeepCopy(),
+		}
+		return true
+	} else if !reflect.DeepEqual(status.VeleroSchedule.Status, &veleroSchedule.Status) {
+		status.VeleroSchedule.Status = veleroSchedule.Status.DeepCopy()
+		return true
+	}
+	return false
+}
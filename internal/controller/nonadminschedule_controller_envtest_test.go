@@ -0,0 +1,120 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	velerov1 "github.com/vmware-tanzu/velero/pkg/apis/velero/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	nacv1alpha1 "github.com/migtools/oadp-non-admin/api/v1alpha1"
+)
+
+var _ = Describe("NonAdminSchedule controller", func() {
+	var namespace string
+	var reconciler *NonAdminScheduleReconciler
+
+	BeforeEach(func() {
+		namespace = createTestNamespace("nas-test-")
+		reconciler = &NonAdminScheduleReconciler{Client: k8sClient, Scheme: k8sClient.Scheme(), OADPNamespace: envtestOADPNamespace}
+	})
+
+	reconcileUntilSettled := func(req ctrl.Request) {
+		for i := 0; i < 5; i++ {
+			_, err := reconciler.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+		}
+	}
+
+	newAcceptedSchedule := func(name string) (*nacv1alpha1.NonAdminSchedule, ctrl.Request) {
+		nas := &nacv1alpha1.NonAdminSchedule{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: nacv1alpha1.NonAdminScheduleSpec{
+				ScheduleSpec: &velerov1.ScheduleSpec{
+					Schedule: "@daily",
+					Template: velerov1.BackupSpec{},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, nas)).To(Succeed())
+		req := ctrl.Request{NamespacedName: types.NamespacedName{Name: nas.Name, Namespace: namespace}}
+		reconcileUntilSettled(req)
+		Expect(k8sClient.Get(ctx, req.NamespacedName, nas)).To(Succeed())
+		Expect(nas.Status.VeleroSchedule).NotTo(BeNil())
+		return nas, req
+	}
+
+	When("the VeleroSchedule's Template.IncludedNamespaces drifts away from the NonAdminSchedule namespace", func() {
+		It("corrects the drift back on the next reconcile", func() {
+			nas, req := newAcceptedSchedule("nas-drift")
+
+			veleroSchedule := &velerov1.Schedule{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: nas.Status.VeleroSchedule.Name, Namespace: envtestOADPNamespace}, veleroSchedule)).To(Succeed())
+
+			// Simulate drift, e.g. an admin or another controller editing the VeleroSchedule directly.
+			veleroSchedule.Spec.Template.IncludedNamespaces = []string{"some-other-namespace"}
+			Expect(k8sClient.Update(ctx, veleroSchedule)).To(Succeed())
+
+			reconcileUntilSettled(req)
+
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: veleroSchedule.Name, Namespace: envtestOADPNamespace}, veleroSchedule)).To(Succeed())
+			Expect(veleroSchedule.Spec.Template.IncludedNamespaces).To(Equal([]string{namespace}))
+		})
+	})
+
+	When("the VeleroSchedule has created Backups not yet represented in this API", func() {
+		It("adopts each one as a NonAdminBackup owned by the NonAdminSchedule", func() {
+			nas, req := newAcceptedSchedule("nas-adopt")
+
+			veleroBackup := &velerov1.Backup{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      nas.Status.VeleroSchedule.Name + "-20260101000000",
+					Namespace: envtestOADPNamespace,
+					Labels:    map[string]string{velerov1.ScheduleNameLabel: nas.Status.VeleroSchedule.Name},
+				},
+			}
+			Expect(k8sClient.Create(ctx, veleroBackup)).To(Succeed())
+			veleroBackup.Status.Phase = velerov1.BackupPhaseCompleted
+			Expect(k8sClient.Status().Update(ctx, veleroBackup)).To(Succeed())
+
+			reconcileUntilSettled(req)
+
+			nab := &nacv1alpha1.NonAdminBackup{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: veleroBackup.Name, Namespace: namespace}, nab)).To(Succeed())
+			Expect(nab.Status.Phase).To(Equal(nacv1alpha1.NonAdminBackupPhaseCreated))
+			Expect(nab.Status.VeleroBackup).NotTo(BeNil())
+			Expect(nab.Status.VeleroBackup.Name).To(Equal(veleroBackup.Name))
+			Expect(nab.OwnerReferences).To(HaveLen(1))
+			Expect(nab.OwnerReferences[0].Name).To(Equal(nas.Name))
+			Expect(nab.OwnerReferences[0].Kind).To(Equal("NonAdminSchedule"))
+
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: veleroBackup.Name, Namespace: envtestOADPNamespace}, veleroBackup)).To(Succeed())
+			Expect(veleroBackup.Annotations).To(HaveKeyWithValue("openshift.io/oadp-non-admin-backup-name", nab.Name))
+
+			// A further reconcile must not adopt the same VeleroBackup a second time.
+			reconcileUntilSettled(req)
+			var nabList nacv1alpha1.NonAdminBackupList
+			Expect(k8sClient.List(ctx, &nabList, client.InNamespace(namespace))).To(Succeed())
+			Expect(nabList.Items).To(HaveLen(1))
+		})
+	})
+})
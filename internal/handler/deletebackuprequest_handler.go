@@ -0,0 +1,48 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handler
+
+import (
+	"context"
+
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+// DeleteBackupRequestHandler contains event handlers for Velero DeleteBackupRequest objects. The
+// DeleteBackupRequest carries the same NonAdminBackup origin annotations as the VeleroBackup it targets,
+// so the requeue logic is shared with VeleroBackupHandler.
+type DeleteBackupRequestHandler struct{}
+
+// Create event handler
+func (DeleteBackupRequestHandler) Create(ctx context.Context, evt event.CreateEvent, q workqueue.RateLimitingInterface) {
+	enqueueFromVeleroBackupAnnotations(ctx, evt.Object, q)
+}
+
+// Update event handler
+func (DeleteBackupRequestHandler) Update(ctx context.Context, evt event.UpdateEvent, q workqueue.RateLimitingInterface) {
+	enqueueFromVeleroBackupAnnotations(ctx, evt.ObjectNew, q)
+}
+
+// Delete event handler
+func (DeleteBackupRequestHandler) Delete(ctx context.Context, evt event.DeleteEvent, q workqueue.RateLimitingInterface) {
+	enqueueFromVeleroBackupAnnotations(ctx, evt.Object, q)
+}
+
+// Generic event handler
+func (DeleteBackupRequestHandler) Generic(_ context.Context, _ event.GenericEvent, _ workqueue.RateLimitingInterface) {
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package handler contains all event handlers used by the project controllers
+package handler
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/migtools/oadp-non-admin/internal/common/function"
+)
+
+const veleroBackupHandlerKey = "VeleroBackupHandler"
+
+// VeleroBackupHandler contains event handlers for Velero Backup objects
+type VeleroBackupHandler struct{}
+
+// Create event handler
+func (VeleroBackupHandler) Create(ctx context.Context, evt event.CreateEvent, q workqueue.RateLimitingInterface) {
+	enqueueFromVeleroBackupAnnotations(ctx, evt.Object, q)
+}
+
+// Update event handler
+func (VeleroBackupHandler) Update(ctx context.Context, evt event.UpdateEvent, q workqueue.RateLimitingInterface) {
+	enqueueFromVeleroBackupAnnotations(ctx, evt.ObjectNew, q)
+}
+
+// Delete event handler
+func (VeleroBackupHandler) Delete(ctx context.Context, evt event.DeleteEvent, q workqueue.RateLimitingInterface) {
+	enqueueFromVeleroBackupAnnotations(ctx, evt.Object, q)
+}
+
+// Generic event handler
+func (VeleroBackupHandler) Generic(_ context.Context, _ event.GenericEvent, _ workqueue.RateLimitingInterface) {
+}
+
+// enqueueFromVeleroBackupAnnotations requeues the NonAdminBackup that originated the given VeleroBackup,
+// identified through the annotations set by the NonAdminBackupReconciler
+func enqueueFromVeleroBackupAnnotations(ctx context.Context, object client.Object, q workqueue.RateLimitingInterface) {
+	logger := function.GetLogger(ctx, object, veleroBackupHandlerKey)
+
+	name, namespace := nonAdminOriginFromAnnotations(object,
+		function.NabOriginNameAnnotation,
+		function.NabOriginNamespaceAnnotation)
+	if name == "" || namespace == "" {
+		logger.V(1).Info("VeleroBackup has no NonAdminBackup origin annotations, skipping")
+		return
+	}
+
+	q.Add(reconcile.Request{
+		NamespacedName: types.NamespacedName{Name: name, Namespace: namespace},
+	})
+}
+
+func nonAdminOriginFromAnnotations(object metav1.Object, nameKey, namespaceKey string) (string, string) {
+	annotations := object.GetAnnotations()
+	return annotations[nameKey], annotations[namespaceKey]
+}
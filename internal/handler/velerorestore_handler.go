@@ -0,0 +1,71 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handler
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/migtools/oadp-non-admin/internal/common/function"
+)
+
+const veleroRestoreHandlerKey = "VeleroRestoreHandler"
+
+// VeleroRestoreHandler contains event handlers for Velero Restore objects
+type VeleroRestoreHandler struct{}
+
+// Create event handler
+func (VeleroRestoreHandler) Create(ctx context.Context, evt event.CreateEvent, q workqueue.RateLimitingInterface) {
+	enqueueFromVeleroRestoreAnnotations(ctx, evt.Object, q)
+}
+
+// Update event handler
+func (VeleroRestoreHandler) Update(ctx context.Context, evt event.UpdateEvent, q workqueue.RateLimitingInterface) {
+	enqueueFromVeleroRestoreAnnotations(ctx, evt.ObjectNew, q)
+}
+
+// Delete event handler
+func (VeleroRestoreHandler) Delete(ctx context.Context, evt event.DeleteEvent, q workqueue.RateLimitingInterface) {
+	enqueueFromVeleroRestoreAnnotations(ctx, evt.Object, q)
+}
+
+// Generic event handler
+func (VeleroRestoreHandler) Generic(_ context.Context, _ event.GenericEvent, _ workqueue.RateLimitingInterface) {
+}
+
+// enqueueFromVeleroRestoreAnnotations requeues the NonAdminRestore that originated the given VeleroRestore,
+// identified through the annotations set by the NonAdminRestoreReconciler
+func enqueueFromVeleroRestoreAnnotations(ctx context.Context, object client.Object, q workqueue.RateLimitingInterface) {
+	logger := function.GetLogger(ctx, object, veleroRestoreHandlerKey)
+
+	name, namespace := nonAdminOriginFromAnnotations(object,
+		function.NarOriginNameAnnotation,
+		function.NarOriginNamespaceAnnotation)
+	if name == "" || namespace == "" {
+		logger.V(1).Info("VeleroRestore has no NonAdminRestore origin annotations, skipping")
+		return
+	}
+
+	q.Add(reconcile.Request{
+		NamespacedName: types.NamespacedName{Name: name, Namespace: namespace},
+	})
+}
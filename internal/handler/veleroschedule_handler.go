@@ -0,0 +1,71 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handler
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/migtools/oadp-non-admin/internal/common/function"
+)
+
+const veleroScheduleHandlerKey = "VeleroScheduleHandler"
+
+// VeleroScheduleHandler contains event handlers for Velero Schedule objects
+type VeleroScheduleHandler struct{}
+
+// Create event handler
+func (VeleroScheduleHandler) Create(ctx context.Context, evt event.CreateEvent, q workqueue.RateLimitingInterface) {
+	enqueueFromVeleroScheduleAnnotations(ctx, evt.Object, q)
+}
+
+// Update event handler
+func (VeleroScheduleHandler) Update(ctx context.Context, evt event.UpdateEvent, q workqueue.RateLimitingInterface) {
+	enqueueFromVeleroScheduleAnnotations(ctx, evt.ObjectNew, q)
+}
+
+// Delete event handler
+func (VeleroScheduleHandler) Delete(ctx context.Context, evt event.DeleteEvent, q workqueue.RateLimitingInterface) {
+	enqueueFromVeleroScheduleAnnotations(ctx, evt.Object, q)
+}
+
+// Generic event handler
+func (VeleroScheduleHandler) Generic(_ context.Context, _ event.GenericEvent, _ workqueue.RateLimitingInterface) {
+}
+
+// enqueueFromVeleroScheduleAnnotations requeues the NonAdminSchedule that originated the given VeleroSchedule,
+// identified through the annotations set by the NonAdminScheduleReconciler
+func enqueueFromVeleroScheduleAnnotations(ctx context.Context, object client.Object, q workqueue.RateLimitingInterface) {
+	logger := function.GetLogger(ctx, object, veleroScheduleHandlerKey)
+
+	name, namespace := nonAdminOriginFromAnnotations(object,
+		function.NasOriginNameAnnotation,
+		function.NasOriginNamespaceAnnotation)
+	if name == "" || namespace == "" {
+		logger.V(1).Info("VeleroSchedule has no NonAdminSchedule origin annotations, skipping")
+		return
+	}
+
+	q.Add(reconcile.Request{
+		NamespacedName: types.NamespacedName{Name: name, Namespace: namespace},
+	})
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handler
+
+import (
+	"context"
+
+	velerov1 "github.com/vmware-tanzu/velero/pkg/apis/velero/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/migtools/oadp-non-admin/internal/common/function"
+)
+
+const volumeSnapshotHandlerKey = "VolumeSnapshotHandler"
+
+// VolumeSnapshotHandler contains event handlers for VolumeSnapshot objects created by Velero's CSI plugin.
+// Unlike the other handlers in this package, it needs a Client: a VolumeSnapshot only carries the Velero
+// Backup name label, not the NonAdminBackup origin annotations, so the owning VeleroBackup must be fetched
+// to resolve which NonAdminBackup to requeue.
+type VolumeSnapshotHandler struct {
+	Client        client.Client
+	OADPNamespace string
+}
+
+// Create event handler
+func (h VolumeSnapshotHandler) Create(ctx context.Context, evt event.CreateEvent, q workqueue.RateLimitingInterface) {
+	h.enqueue(ctx, evt.Object, q)
+}
+
+// Update event handler
+func (h VolumeSnapshotHandler) Update(ctx context.Context, evt event.UpdateEvent, q workqueue.RateLimitingInterface) {
+	h.enqueue(ctx, evt.ObjectNew, q)
+}
+
+// Delete event handler
+func (h VolumeSnapshotHandler) Delete(ctx context.Context, evt event.DeleteEvent, q workqueue.RateLimitingInterface) {
+	h.enqueue(ctx, evt.Object, q)
+}
+
+// Generic event handler
+func (VolumeSnapshotHandler) Generic(_ context.Context, _ event.GenericEvent, _ workqueue.RateLimitingInterface) {
+}
+
+// enqueue requeues the NonAdminBackup that originated the VeleroBackup referenced by the VolumeSnapshot's
+// Velero Backup name label, so NonAdminBackupStatus.VolumeSnapshots can be refreshed on readiness
+// transitions that occur after the VeleroBackup itself already reached the Completed phase.
+func (h VolumeSnapshotHandler) enqueue(ctx context.Context, object client.Object, q workqueue.RateLimitingInterface) {
+	logger := function.GetLogger(ctx, object, volumeSnapshotHandlerKey)
+
+	veleroBackupName := object.GetLabels()[velerov1.BackupNameLabel]
+	if veleroBackupName == "" {
+		logger.V(1).Info("VolumeSnapshot has no Velero Backup name label, skipping")
+		return
+	}
+
+	veleroBackup := velerov1.Backup{}
+	if err := h.Client.Get(ctx, client.ObjectKey{Name: veleroBackupName, Namespace: h.OADPNamespace}, &veleroBackup); err != nil {
+		logger.V(1).Info("Unable to fetch VeleroBackup referenced by VolumeSnapshot, skipping", "error", err.Error())
+		return
+	}
+
+	name, namespace := nonAdminOriginFromAnnotations(&veleroBackup,
+		function.NabOriginNameAnnotation,
+		function.NabOriginNamespaceAnnotation)
+	if name == "" || namespace == "" {
+		logger.V(1).Info("VeleroBackup has no NonAdminBackup origin annotations, skipping")
+		return
+	}
+
+	q.Add(reconcile.Request{
+		NamespacedName: types.NamespacedName{Name: name, Namespace: namespace},
+	})
+}
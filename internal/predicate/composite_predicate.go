@@ -0,0 +1,83 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package predicate contains all event filters used by the project controllers
+package predicate
+
+import (
+	"context"
+
+	snapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v7/apis/volumesnapshot/v1"
+	velerov1 "github.com/vmware-tanzu/velero/pkg/apis/velero/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+// CompositePredicate composes the NonAdminBackup predicate, the VeleroBackup predicate, the
+// DeleteBackupRequest predicate and the VolumeSnapshot predicate, so a single predicate.Funcs
+// implementation can be used to filter events from all four object kinds on the same watch
+type CompositePredicate struct {
+	NonAdminBackupPredicate      NonAdminBackupPredicate
+	VeleroBackupPredicate        VeleroBackupPredicate
+	DeleteBackupRequestPredicate DeleteBackupRequestPredicate
+	VolumeSnapshotPredicate      VolumeSnapshotPredicate
+}
+
+// Create event filter
+func (p CompositePredicate) Create(evt event.CreateEvent) bool {
+	switch evt.Object.(type) {
+	case *velerov1.Backup:
+		return p.VeleroBackupPredicate.Create(context.Background(), evt)
+	case *velerov1.DeleteBackupRequest:
+		return p.DeleteBackupRequestPredicate.Create(context.Background(), evt)
+	case *snapshotv1.VolumeSnapshot:
+		return p.VolumeSnapshotPredicate.Create(context.Background(), evt)
+	default:
+		return p.NonAdminBackupPredicate.Create(context.Background(), evt)
+	}
+}
+
+// Update event filter
+func (p CompositePredicate) Update(evt event.UpdateEvent) bool {
+	switch evt.ObjectNew.(type) {
+	case *velerov1.Backup:
+		return p.VeleroBackupPredicate.Update(context.Background(), evt)
+	case *velerov1.DeleteBackupRequest:
+		return p.DeleteBackupRequestPredicate.Update(context.Background(), evt)
+	case *snapshotv1.VolumeSnapshot:
+		return p.VolumeSnapshotPredicate.Update(context.Background(), evt)
+	default:
+		return p.NonAdminBackupPredicate.Update(context.Background(), evt)
+	}
+}
+
+// Delete event filter
+func (p CompositePredicate) Delete(evt event.DeleteEvent) bool {
+	switch evt.Object.(type) {
+	case *velerov1.Backup:
+		return p.VeleroBackupPredicate.Delete(context.Background(), evt)
+	case *velerov1.DeleteBackupRequest:
+		return p.DeleteBackupRequestPredicate.Delete(context.Background(), evt)
+	case *snapshotv1.VolumeSnapshot:
+		return p.VolumeSnapshotPredicate.Delete(context.Background(), evt)
+	default:
+		return p.NonAdminBackupPredicate.Delete(context.Background(), evt)
+	}
+}
+
+// Generic event filter
+func (p CompositePredicate) Generic(event.GenericEvent) bool {
+	return false
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package predicate
+
+import (
+	"context"
+	"reflect"
+
+	velerov1 "github.com/vmware-tanzu/velero/pkg/apis/velero/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	"github.com/migtools/oadp-non-admin/internal/common/function"
+)
+
+const deleteBackupRequestPredicateKey = "DeleteBackupRequestPredicate"
+
+// DeleteBackupRequestPredicate contains event filters for Velero DeleteBackupRequest objects originated
+// from NonAdminBackup deletion
+type DeleteBackupRequestPredicate struct {
+	OADPNamespace string
+}
+
+// Create event filter
+func (p DeleteBackupRequestPredicate) Create(ctx context.Context, evt event.CreateEvent) bool {
+	return p.accept(ctx, evt.Object, "Create")
+}
+
+// Update event filter
+func (p DeleteBackupRequestPredicate) Update(ctx context.Context, evt event.UpdateEvent) bool {
+	logger := function.GetLogger(ctx, evt.ObjectNew, deleteBackupRequestPredicateKey)
+
+	if evt.ObjectNew.GetNamespace() != p.OADPNamespace {
+		logger.V(1).Info("Rejected Update event, DeleteBackupRequest is not in the OADP namespace")
+		return false
+	}
+
+	oldDBR, ok := evt.ObjectOld.(*velerov1.DeleteBackupRequest)
+	newDBR, okNew := evt.ObjectNew.(*velerov1.DeleteBackupRequest)
+	if !ok || !okNew {
+		logger.V(1).Info("Rejected Update event, unable to cast to DeleteBackupRequest")
+		return false
+	}
+
+	if reflect.DeepEqual(oldDBR.Status, newDBR.Status) {
+		logger.V(1).Info("Rejected Update event, DeleteBackupRequest Status unchanged")
+		return false
+	}
+
+	logger.V(1).Info("Accepted Update event")
+	return true
+}
+
+// Delete event filter
+func (p DeleteBackupRequestPredicate) Delete(ctx context.Context, evt event.DeleteEvent) bool {
+	return p.accept(ctx, evt.Object, "Delete")
+}
+
+func (p DeleteBackupRequestPredicate) accept(ctx context.Context, object client.Object, eventName string) bool {
+	logger := function.GetLogger(ctx, object, deleteBackupRequestPredicateKey)
+
+	if object.GetNamespace() != p.OADPNamespace {
+		logger.V(1).Info("Rejected " + eventName + " event, DeleteBackupRequest is not in the OADP namespace")
+		return false
+	}
+
+	logger.V(1).Info("Accepted " + eventName + " event")
+	return true
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package predicate
+
+import (
+	"context"
+
+	velerov1 "github.com/vmware-tanzu/velero/pkg/apis/velero/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+// RestoreCompositePredicate composes the NonAdminRestore predicate and the VeleroRestore predicate, so a single
+// predicate.Funcs implementation can be used to filter events from both object kinds on the same watch
+type RestoreCompositePredicate struct {
+	NonAdminRestorePredicate NonAdminRestorePredicate
+	VeleroRestorePredicate   VeleroRestorePredicate
+}
+
+// Create event filter
+func (p RestoreCompositePredicate) Create(evt event.CreateEvent) bool {
+	if _, ok := evt.Object.(*velerov1.Restore); ok {
+		return p.VeleroRestorePredicate.Create(context.Background(), evt)
+	}
+	return p.NonAdminRestorePredicate.Create(context.Background(), evt)
+}
+
+// Update event filter
+func (p RestoreCompositePredicate) Update(evt event.UpdateEvent) bool {
+	if _, ok := evt.ObjectNew.(*velerov1.Restore); ok {
+		return p.VeleroRestorePredicate.Update(context.Background(), evt)
+	}
+	return p.NonAdminRestorePredicate.Update(context.Background(), evt)
+}
+
+// Delete event filter
+func (p RestoreCompositePredicate) Delete(evt event.DeleteEvent) bool {
+	if _, ok := evt.Object.(*velerov1.Restore); ok {
+		return p.VeleroRestorePredicate.Delete(context.Background(), evt)
+	}
+	return p.NonAdminRestorePredicate.Delete(context.Background(), evt)
+}
+
+// Generic event filter
+func (p RestoreCompositePredicate) Generic(event.GenericEvent) bool {
+	return false
+}
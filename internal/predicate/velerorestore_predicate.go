@@ -0,0 +1,82 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package predicate
+
+import (
+	"context"
+	"reflect"
+
+	velerov1 "github.com/vmware-tanzu/velero/pkg/apis/velero/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	"github.com/migtools/oadp-non-admin/internal/common/function"
+)
+
+const veleroRestorePredicateKey = "VeleroRestorePredicate"
+
+// VeleroRestorePredicate contains event filters for Velero Restore objects originated from NonAdminRestore objects
+type VeleroRestorePredicate struct {
+	OADPNamespace string
+}
+
+// Create event filter
+func (p VeleroRestorePredicate) Create(ctx context.Context, evt event.CreateEvent) bool {
+	return p.accept(ctx, evt.Object, "Create")
+}
+
+// Update event filter
+func (p VeleroRestorePredicate) Update(ctx context.Context, evt event.UpdateEvent) bool {
+	logger := function.GetLogger(ctx, evt.ObjectNew, veleroRestorePredicateKey)
+
+	if evt.ObjectNew.GetNamespace() != p.OADPNamespace {
+		logger.V(1).Info("Rejected Update event, VeleroRestore is not in the OADP namespace")
+		return false
+	}
+
+	oldRestore, ok := evt.ObjectOld.(*velerov1.Restore)
+	newRestore, okNew := evt.ObjectNew.(*velerov1.Restore)
+	if !ok || !okNew {
+		logger.V(1).Info("Rejected Update event, unable to cast to VeleroRestore")
+		return false
+	}
+
+	if reflect.DeepEqual(oldRestore.Status, newRestore.Status) {
+		logger.V(1).Info("Rejected Update event, VeleroRestore Status unchanged")
+		return false
+	}
+
+	logger.V(1).Info("Accepted Update event")
+	return true
+}
+
+// Delete event filter
+func (p VeleroRestorePredicate) Delete(ctx context.Context, evt event.DeleteEvent) bool {
+	return p.accept(ctx, evt.Object, "Delete")
+}
+
+func (p VeleroRestorePredicate) accept(ctx context.Context, object client.Object, eventName string) bool {
+	logger := function.GetLogger(ctx, object, veleroRestorePredicateKey)
+
+	if object.GetNamespace() != p.OADPNamespace {
+		logger.V(1).Info("Rejected " + eventName + " event, VeleroRestore is not in the OADP namespace")
+		return false
+	}
+
+	logger.V(1).Info("Accepted " + eventName + " event")
+	return true
+}
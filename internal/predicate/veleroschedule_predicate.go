@@ -0,0 +1,87 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package predicate
+
+import (
+	"context"
+	"reflect"
+
+	velerov1 "github.com/vmware-tanzu/velero/pkg/apis/velero/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	"github.com/migtools/oadp-non-admin/internal/common/function"
+)
+
+const veleroSchedulePredicateKey = "VeleroSchedulePredicate"
+
+// VeleroSchedulePredicate contains event filters for Velero Schedule objects originated from
+// NonAdminSchedule objects
+type VeleroSchedulePredicate struct {
+	OADPNamespace string
+}
+
+// Create event filter
+func (p VeleroSchedulePredicate) Create(ctx context.Context, evt event.CreateEvent) bool {
+	return p.accept(ctx, evt.Object, "Create")
+}
+
+// Update event filter
+//
+// Unlike the VeleroBackup and VeleroRestore predicates, which only need to wake their reconciler on
+// Status changes, the NonAdminSchedule reconciler also corrects VeleroSchedule Template.IncludedNamespaces
+// drift, so a Spec-only edit (e.g. an admin editing the VeleroSchedule directly) must be accepted too.
+func (p VeleroSchedulePredicate) Update(ctx context.Context, evt event.UpdateEvent) bool {
+	logger := function.GetLogger(ctx, evt.ObjectNew, veleroSchedulePredicateKey)
+
+	if evt.ObjectNew.GetNamespace() != p.OADPNamespace {
+		logger.V(1).Info("Rejected Update event, VeleroSchedule is not in the OADP namespace")
+		return false
+	}
+
+	oldSchedule, ok := evt.ObjectOld.(*velerov1.Schedule)
+	newSchedule, okNew := evt.ObjectNew.(*velerov1.Schedule)
+	if !ok || !okNew {
+		logger.V(1).Info("Rejected Update event, unable to cast to VeleroSchedule")
+		return false
+	}
+
+	if reflect.DeepEqual(oldSchedule.Status, newSchedule.Status) && reflect.DeepEqual(oldSchedule.Spec, newSchedule.Spec) {
+		logger.V(1).Info("Rejected Update event, VeleroSchedule Status and Spec unchanged")
+		return false
+	}
+
+	logger.V(1).Info("Accepted Update event")
+	return true
+}
+
+// Delete event filter
+func (p VeleroSchedulePredicate) Delete(ctx context.Context, evt event.DeleteEvent) bool {
+	return p.accept(ctx, evt.Object, "Delete")
+}
+
+func (p VeleroSchedulePredicate) accept(ctx context.Context, object client.Object, eventName string) bool {
+	logger := function.GetLogger(ctx, object, veleroSchedulePredicateKey)
+
+	if object.GetNamespace() != p.OADPNamespace {
+		logger.V(1).Info("Rejected " + eventName + " event, VeleroSchedule is not in the OADP namespace")
+		return false
+	}
+
+	logger.V(1).Info("Accepted " + eventName + " event")
+	return true
+}
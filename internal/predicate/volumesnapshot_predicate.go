@@ -0,0 +1,81 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package predicate
+
+import (
+	"context"
+	"reflect"
+
+	snapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v7/apis/volumesnapshot/v1"
+	velerov1 "github.com/vmware-tanzu/velero/pkg/apis/velero/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	"github.com/migtools/oadp-non-admin/internal/common/function"
+)
+
+const volumeSnapshotPredicateKey = "VolumeSnapshotPredicate"
+
+// VolumeSnapshotPredicate contains event filters for VolumeSnapshot objects created by Velero's CSI plugin
+// on behalf of a VeleroBackup
+type VolumeSnapshotPredicate struct{}
+
+// Create event filter
+func (p VolumeSnapshotPredicate) Create(ctx context.Context, evt event.CreateEvent) bool {
+	return p.hasBackupNameLabel(ctx, evt.Object, "Create")
+}
+
+// Update event filter
+func (p VolumeSnapshotPredicate) Update(ctx context.Context, evt event.UpdateEvent) bool {
+	logger := function.GetLogger(ctx, evt.ObjectNew, volumeSnapshotPredicateKey)
+
+	if !p.hasBackupNameLabel(ctx, evt.ObjectNew, "Update") {
+		return false
+	}
+
+	oldSnapshot, ok := evt.ObjectOld.(*snapshotv1.VolumeSnapshot)
+	newSnapshot, okNew := evt.ObjectNew.(*snapshotv1.VolumeSnapshot)
+	if !ok || !okNew {
+		logger.V(1).Info("Rejected Update event, unable to cast to VolumeSnapshot")
+		return false
+	}
+
+	if reflect.DeepEqual(oldSnapshot.Status, newSnapshot.Status) {
+		logger.V(1).Info("Rejected Update event, VolumeSnapshot Status unchanged")
+		return false
+	}
+
+	logger.V(1).Info("Accepted Update event")
+	return true
+}
+
+// Delete event filter
+func (VolumeSnapshotPredicate) Delete(context.Context, event.DeleteEvent) bool {
+	return false
+}
+
+func (VolumeSnapshotPredicate) hasBackupNameLabel(ctx context.Context, object client.Object, eventName string) bool {
+	logger := function.GetLogger(ctx, object, volumeSnapshotPredicateKey)
+
+	if object.GetLabels()[velerov1.BackupNameLabel] == "" {
+		logger.V(1).Info("Rejected " + eventName + " event, VolumeSnapshot has no Velero Backup name label")
+		return false
+	}
+
+	logger.V(1).Info("Accepted " + eventName + " event")
+	return true
+}